@@ -2,31 +2,103 @@ package proctorexam
 
 import (
 	"bytes"
+	"context"
 	"crypto/hmac"
+	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
-	"math/rand"
+	"log"
+	"math/big"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 )
 
 // http://docs.proctorexam.com/v3/apidoapi.html
 
-const apiPrefix string = "/api/v3"
+// defaultAPIVersion version of the API the sdk is written against
+const defaultAPIVersion string = "v3"
+
+const apiPrefix string = "/api/" + defaultAPIVersion
+
+// Version of the sdk, sent in the default User-Agent
+const Version string = "0.1.0"
+
+const defaultUserAgent string = "proctorexam-go/" + Version
+
+// defaultMaxIdleConnsPerHost idle connections kept open to the API host by the
+// default http client, so polling loops reuse them
+const defaultMaxIdleConnsPerHost = 16
+
+// apiURL production ProctorExam host
+const apiURL string = "https://protos.proctorexam.com"
+
+// ErrMissingCredentials is returned by New when the API key or the secret key
+// has not been supplied
+var ErrMissingCredentials = errors.New("proctorexam: api key and secret key are required")
+
+// ErrSignatureMismatch is returned in self-verify mode when the signature
+// recomputed from the encoded query differs from the one sent
+var ErrSignatureMismatch = errors.New("proctorexam: signature does not match the encoded query")
+
+// ErrNoBaseURL is returned when a request is built by a client whose base URL
+// has been unset
+var ErrNoBaseURL = errors.New("proctorexam: no base url configured")
+
+// ErrClientClosed is returned for calls issued after Close
+var ErrClientClosed = errors.New("proctorexam: client closed")
 
 // Exam data struct
 type Exam struct {
 	ID          int64  `json:"id"`
 	InstituteID int64  `json:"institute_id"`
 	Name        string `json:"name"`
+	// ResultsReleased whether students can see their results
+	ResultsReleased bool `json:"results_released"`
+	Archived        bool `json:"archived"`
+	// Status lifecycle state, empty when the server does not report it
+	Status ExamStatus `json:"status"`
+	// StartsAt and EndsAt exam window, zero when not scheduled
+	StartsAt time.Time `json:"-"`
+	EndsAt   time.Time `json:"-"`
+}
+
+// UnmarshalJSON decodes the starts_at and ends_at RFC3339 times, tolerating
+// null and empty values
+func (e *Exam) UnmarshalJSON(data []byte) error {
+	type alias Exam
+	aux := struct {
+		*alias
+		StartsAt string `json:"starts_at"`
+		EndsAt   string `json:"ends_at"`
+	}{alias: (*alias)(e)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	var err error
+	if e.StartsAt, err = parseOptionalTime(aux.StartsAt); err != nil {
+		return err
+	}
+	e.EndsAt, err = parseOptionalTime(aux.EndsAt)
+	return err
+}
+
+// parseOptionalTime parses an RFC3339 time, returning the zero time for an
+// empty value
+func parseOptionalTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
 }
 
 // User internal data of user response
@@ -41,21 +113,49 @@ type User struct {
 
 // Student nested object of GET /exams/id/show_student
 type Student struct {
-	ID     int64  `json:"id"`
-	Email  string `json:"email"`
-	Name   string `json:"name"`
-	Status string `json:"status"`
-	ExamID int64  `json:"exam_id"`
+	ID     int64         `json:"id"`
+	Email  string        `json:"email"`
+	Name   string        `json:"name"`
+	Status StudentStatus `json:"status"`
+	ExamID int64         `json:"exam_id"`
+	// links returned by show_student to send the candidate into the exam
+	SSOLink          string `json:"sso_link"`
+	SecureBrowserURL string `json:"secure_browser_url"`
+	UploadLink       string `json:"upload_link"`
+	// CheckURL system check page the candidate runs before the exam
+	CheckURL string `json:"check_url"`
 }
 
 // API ProctorExam sdk metadata
+//
+// An *API is safe for concurrent use by multiple goroutines: its
+// configuration is not modified after New and the state updated by the calls
+// (request stats, rate limits, nonces) is guarded by mutexes.
 type API struct {
 	baseURL      *url.URL
+	customURL    bool
 	httpClient   *http.Client
+	customClient bool
+	timeout      *time.Duration
+	maxIdleConns int
 	userAgent    string
+	apiVersion   string
 	debug        bool
+	logger       *log.Logger
 	apiKey       string
 	apiSecretKey string
+	ctx          context.Context
+	callTimeout  time.Duration
+	stats        *statsRecorder
+	rateLimit    *rateLimitRecorder
+	proctors     *proctorCache
+	nonces       NonceStore
+	maxRetries   int
+	retryDelay   time.Duration
+	selfVerify   bool
+	// done is cancelled by Close, aborting the in-flight requests
+	done     context.Context
+	shutdown context.CancelFunc
 }
 
 // Option is a functional option for configuring the API client
@@ -77,39 +177,256 @@ func (api *API) parseOptions(opts ...Option) error {
 	return nil
 }
 
-// BaseURL allows overriding of API client baseURL for testing
+// BaseURL allows overriding of API client baseURL for testing. It defaults to
+// the ProctorExam production host.
 func BaseURL(baseURL *url.URL) Option {
 	return func(api *API) error {
 		api.baseURL = baseURL
+		api.customURL = true
+		return nil
+	}
+}
+
+// APIKey sets the token sent in the Authorization header
+func APIKey(key string) Option {
+	return func(api *API) error {
+		api.apiKey = key
+		return nil
+	}
+}
+
+// SecretKey sets the secret used to sign the request params
+func SecretKey(secret string) Option {
+	return func(api *API) error {
+		api.apiSecretKey = secret
 		return nil
 	}
 }
 
-// New creates a new API client
+// Credentials sets both the api key and the secret key
+func Credentials(key, secret string) Option {
+	return func(api *API) error {
+		api.apiKey = key
+		api.apiSecretKey = secret
+		return nil
+	}
+}
+
+// HTTPClient replaces the default http client, e.g. to configure a proxy,
+// TLS settings or an instrumented transport. It takes precedence over
+// Timeout, which is ignored when a client is supplied.
+func HTTPClient(client *http.Client) Option {
+	return func(api *API) error {
+		if client == nil {
+			return errors.New("proctorexam: nil http client")
+		}
+		api.httpClient = client
+		api.customClient = true
+		return nil
+	}
+}
+
+// Timeout changes the timeout of the default http client (30s). Like
+// http.Client, a zero timeout means no timeout.
+func Timeout(d time.Duration) Option {
+	return func(api *API) error {
+		if d < 0 {
+			return errors.New("proctorexam: negative timeout")
+		}
+		api.timeout = &d
+		return nil
+	}
+}
+
+// MaxIdleConnsPerHost changes the number of idle connections the default http
+// client keeps open to the API host (16). Like Timeout, it is ignored when a
+// client is supplied with HTTPClient.
+func MaxIdleConnsPerHost(n int) Option {
+	return func(api *API) error {
+		if n <= 0 {
+			return errors.New("proctorexam: max idle connections must be positive")
+		}
+		api.maxIdleConns = n
+		return nil
+	}
+}
+
+// UserAgent overrides the User-Agent header identifying the integration
+func UserAgent(ua string) Option {
+	return func(api *API) error {
+		api.userAgent = ua
+		return nil
+	}
+}
+
+// APIVersion overrides the API version, "v3" by default, e.g. to test against
+// a staging version. It sets both the Accept media type
+// (application/vnd.procwise.<v>) and the /api/<v> path prefix.
+func APIVersion(v string) Option {
+	return func(api *API) error {
+		if v == "" {
+			return errors.New("proctorexam: empty api version")
+		}
+		api.apiVersion = v
+		return nil
+	}
+}
+
+// Logger enables the debug mode, dumping every request and response to l
+func Logger(l *log.Logger) Option {
+	return func(api *API) error {
+		api.logger = l
+		api.debug = l != nil
+		return nil
+	}
+}
+
+// SelfVerify makes the client parse every query string it builds the way the
+// server does and check the signature still matches before sending, catching
+// encoding bugs client-side. Off by default.
+func SelfVerify(enabled bool) Option {
+	return func(api *API) error {
+		api.selfVerify = enabled
+		return nil
+	}
+}
+
+// ProctorCacheTTL changes how long InstituteProctors keeps the proctor list
+// of an institute (5 minutes). A zero ttl disables the cache.
+func ProctorCacheTTL(ttl time.Duration) Option {
+	return func(api *API) error {
+		if ttl < 0 {
+			return errors.New("proctorexam: negative proctor cache ttl")
+		}
+		api.proctors.ttl = ttl
+		return nil
+	}
+}
+
+// New creates a new API client. Credentials are mandatory unless the base
+// URL is overridden (e.g. to point to a test server).
 func New(opts ...Option) (*API, error) {
-	// url, _ := url.Parse(apiURL)
+	url, _ := url.Parse(apiURL)
 	client := &API{
-		// baseURL: url,
+		baseURL: url,
 		httpClient: &http.Client{
 			Timeout: time.Second * 30,
 		},
-		debug: false,
+		maxIdleConns: defaultMaxIdleConnsPerHost,
+		userAgent:    defaultUserAgent,
+		apiVersion:   defaultAPIVersion,
+		debug:        false,
+		stats:        &statsRecorder{},
+		rateLimit:    &rateLimitRecorder{},
+		proctors:     &proctorCache{ttl: defaultProctorCacheTTL},
+		nonces:       NewMemoryNonceStore(defaultNonceCapacity),
 	}
 
 	if err := client.parseOptions(opts...); err != nil {
 		return nil, err
 	}
 
+	if !client.customClient {
+		if client.timeout != nil {
+			client.httpClient.Timeout = *client.timeout
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.MaxIdleConnsPerHost = client.maxIdleConns
+		client.httpClient.Transport = transport
+	}
+	client.done, client.shutdown = context.WithCancel(context.Background())
+
+	if !client.customURL && (client.apiKey == "" || client.apiSecretKey == "") {
+		return nil, ErrMissingCredentials
+	}
+
 	return client, nil
 }
 
+// WithContext returns a shallow copy of the client whose requests are bound to
+// ctx, so they are aborted when ctx is cancelled or its deadline expires:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+//	defer cancel()
+//	exams, err := api.WithContext(ctx).Exams()
+func (api *API) WithContext(ctx context.Context) *API {
+	if ctx == nil {
+		panic("nil context")
+	}
+	client := *api
+	client.ctx = ctx
+	return &client
+}
+
+// WithTimeout returns a shallow copy of the client whose calls each time out
+// after d, retries included, overriding the client timeout:
+//
+//	students, err := api.WithTimeout(2 * time.Minute).IndexStudents(examID)
+func (api *API) WithTimeout(d time.Duration) *API {
+	client := *api
+	client.callTimeout = d
+	return &client
+}
+
+// Close cancels the in-flight requests and releases the idle connections of
+// the default http client; a client supplied with HTTPClient is left alone as
+// it may be shared. The client, and every copy returned by WithContext, can
+// not be used afterwards.
+func (api *API) Close() error {
+	if api.shutdown != nil {
+		api.shutdown()
+	}
+	if !api.customClient {
+		api.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// bind ties req to the lifetime of the client so Close aborts it. The returned
+// func must be called once the response body has been consumed.
+func (api *API) bind(req *http.Request) (*http.Request, func(), error) {
+	if api.done == nil {
+		return req, func() {}, nil
+	}
+	if api.done.Err() != nil {
+		return nil, nil, ErrClientClosed
+	}
+	ctx, cancel := context.WithCancel(req.Context())
+	stop := context.AfterFunc(api.done, cancel)
+	return req.WithContext(ctx), func() {
+		stop()
+		cancel()
+	}, nil
+}
+
+// context returns the context requests are bound to
+func (api *API) context() context.Context {
+	if api.ctx != nil {
+		return api.ctx
+	}
+	return context.Background()
+}
+
 func (api *API) newGetRequest(path string, params, queryParams map[string]string) (*http.Request, error) {
 	return api.newRequest("GET", path, nil, params, queryParams)
 }
 
-// same function as:
-// https://gist.github.com/almeidabbm/c1e1f184572674f7c7cea193d0b55ea7
-func (api *API) signParams(params map[string]string) string {
+func (api *API) newPostRequest(path string, body interface{}, params, queryParams map[string]string) (*http.Request, error) {
+	return api.newRequest("POST", path, body, params, queryParams)
+}
+
+func (api *API) newPatchRequest(path string, body interface{}, params, queryParams map[string]string) (*http.Request, error) {
+	return api.newRequest("PATCH", path, body, params, queryParams)
+}
+
+func (api *API) newDeleteRequest(path string, params, queryParams map[string]string) (*http.Request, error) {
+	return api.newRequest("DELETE", path, nil, params, queryParams)
+}
+
+// SigningBaseString returns the exact string that is fed into the HMAC when
+// signing a request: the params sorted by key, each rendered as key=value and
+// joined with "?", e.g. "id=17?nonce=123?timestamp=456".
+func SigningBaseString(params map[string]string) string {
 	var keys []string
 	for k := range params {
 		keys = append(keys, k)
@@ -125,90 +442,266 @@ func (api *API) signParams(params map[string]string) string {
 		}
 	}
 
-	hash := hmac.New(sha256.New, []byte(api.apiSecretKey))
-	hash.Write([]byte(baseString))
-	signature := hex.EncodeToString(hash.Sum(nil))
+	return baseString
+}
 
-	// fmt.Printf("baseString: %s\n", baseString)
-	// fmt.Printf("Signature: %s\n", signature)
+// SignParams returns the hex encoded HMAC-SHA256 of the signing base string
+// of params, keyed with secret. It is the signature sent along every request,
+// exposed so integrators can check their own implementation against it.
+//
+// The "?" delimiter of the base string is unusual but it is what the
+// ProctorExam reference implementation uses, see:
+// https://gist.github.com/almeidabbm/c1e1f184572674f7c7cea193d0b55ea7
+func SignParams(secret string, params map[string]string) string {
+	hash := hmac.New(sha256.New, []byte(secret))
+	hash.Write([]byte(SigningBaseString(params)))
+	return hex.EncodeToString(hash.Sum(nil))
+}
 
-	return signature
+func (api *API) signParams(params map[string]string) string {
+	return SignParams(api.apiSecretKey, params)
 }
 
 func (api *API) newRequest(method, path string, body interface{}, params, queryParams map[string]string) (*http.Request, error) {
+	if body == nil {
+		return api.newSignedRequest(method, path, nil, "", params, queryParams)
+	}
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(body); err != nil {
+		return nil, err
+	}
+	return api.newSignedRequest(method, path, buf, "application/json", params, queryParams)
+}
+
+// newSignedRequest builds a request sending body with the given content type,
+// signing params into the query string
+func (api *API) newSignedRequest(method, path string, body io.Reader, contentType string, params, queryParams map[string]string) (*http.Request, error) {
+	if api.baseURL == nil {
+		return nil, ErrNoBaseURL
+	}
+	if api.apiVersion != defaultAPIVersion && strings.HasPrefix(path, apiPrefix+"/") {
+		path = "/api/" + api.apiVersion + strings.TrimPrefix(path, apiPrefix)
+	}
 	rel := &url.URL{Path: path}
 	u := api.baseURL.ResolveReference(rel)
-	var buf io.ReadWriter
-	if body != nil {
-		buf = new(bytes.Buffer)
-		err := json.NewEncoder(buf).Encode(body)
-		if err != nil {
-			return nil, err
-		}
+	if err := api.claimNonce(params); err != nil {
+		return nil, err
 	}
 	signature := api.signParams(params)
 
-	target := fmt.Sprintf("%s?nonce=%s&timestamp=%s&signature=%s",
-		u.String(), params["nonce"], params["timestamp"], signature)
-
-	if len(queryParams) > 0 {
-		for key, value := range queryParams {
-			target = fmt.Sprintf("%s&%s=%s", target, key, value)
+	u.RawQuery = encodeQuery(params["nonce"], params["timestamp"], signature, queryParams)
+	if api.selfVerify {
+		if err := api.verifyQuery(u.RawQuery, params); err != nil {
+			return nil, err
 		}
 	}
 
-	req, err := http.NewRequest(method, target, buf)
+	ctx := context.WithValue(api.context(), signedParamsKey{}, signedParams{params, queryParams})
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
 	if err != nil {
 		return nil, err
 	}
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
 	}
-	req.Header.Set("Accept", "application/vnd.procwise.v3")
+	req.Header.Set("Accept", "application/vnd.procwise."+api.apiVersion)
 	req.Header.Set("User-Agent", api.userAgent)
 	req.Header.Set("Authorization", "Token token="+api.apiKey)
 
 	return req, nil
 }
 
+// BuildRequest returns the signed request the client would send to path,
+// relative to the API prefix (e.g. "/exams/17"), without sending it. It is
+// meant for debugging signature mismatches, e.g. to dump the request or
+// replay it with curl. pathParams are signed but not sent in the query, as
+// the id of "/exams/17"; queryParams are signed and sent.
+func (api *API) BuildRequest(method, path string, pathParams, queryParams map[string]string) (*http.Request, error) {
+	params := getBaseParams()
+	for key, value := range pathParams {
+		params[key] = value
+	}
+	for key, value := range queryParams {
+		params[key] = value
+	}
+	return api.newRequest(method, apiPrefix+path, nil, params, queryParams)
+}
+
+// encodeQuery escapes the query params, keeping nonce, timestamp and signature
+// first as the server expects them
+func encodeQuery(nonce, timestamp, signature string, queryParams map[string]string) string {
+	// url.Values.Encode sorts by key, so the mandatory params go first on
+	// their own
+	rawQuery := fmt.Sprintf("nonce=%s&timestamp=%s&signature=%s",
+		url.QueryEscape(nonce), url.QueryEscape(timestamp), url.QueryEscape(signature))
+
+	extra := url.Values{}
+	for key, value := range queryParams {
+		extra.Set(key, value)
+	}
+	if len(extra) > 0 {
+		rawQuery += "&" + extra.Encode()
+	}
+
+	return rawQuery
+}
+
+// verifyQuery recomputes the signature from rawQuery as parsed server-side.
+// Signed params not sent in the query, such as path ids, are taken from
+// params.
+func (api *API) verifyQuery(rawQuery string, params map[string]string) error {
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrSignatureMismatch, err)
+	}
+	received := make(map[string]string, len(params))
+	for key, value := range params {
+		received[key] = value
+	}
+	for key := range query {
+		if key != "signature" {
+			received[key] = query.Get(key)
+		}
+	}
+	if !hmac.Equal([]byte(api.signParams(received)), []byte(query.Get("signature"))) {
+		return ErrSignatureMismatch
+	}
+	return nil
+}
+
+// debugging reports whether requests and responses are dumped. Without a
+// logger the debug mode is silent.
+func (api *API) debugging() bool {
+	return api.debug && api.logger != nil
+}
+
+// logRequest dumps req to the logger in debug mode. A request that can not be
+// dumped is skipped.
+func (api *API) logRequest(req *http.Request) {
+	if !api.debugging() {
+		return
+	}
+	reqDump, err := httputil.DumpRequest(req, true)
+	if err != nil {
+		api.logger.Printf("proctorexam: could not dump request: %v", err)
+		return
+	}
+	api.logger.Printf("%s", reqDump)
+}
+
 func (api *API) do(req *http.Request, v interface{}) error {
-	if api.debug {
-		reqDump, err := httputil.DumpRequest(req, true)
-		if err != nil {
-			panic(err)
+	_, err := api.send(req, v)
+	return err
+}
+
+// send performs the request, decodes the JSON body into v and returns the
+// response along with its parsed pagination links. Retryable failures are
+// retried as configured by the Retry option.
+func (api *API) send(req *http.Request, v interface{}) (*Response, error) {
+	if api.callTimeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), api.callTimeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+	for retry := 0; ; retry++ {
+		resp, err := api.sendOnce(req, v, retry)
+		if err == nil || retry >= api.maxRetries || !retryable(req, resp) {
+			return resp, err
+		}
+		if err := api.wait(req.Context(), api.retryDelayFor(resp, retry)); err != nil {
+			return resp, err
+		}
+		if req, err = api.resign(req); err != nil {
+			return nil, err
 		}
-		fmt.Printf("%s", reqDump)
 	}
+}
+
+// sendOnce performs a single attempt of the request
+func (api *API) sendOnce(req *http.Request, v interface{}, retries int) (*Response, error) {
+	api.logRequest(req)
 
-	resp, err := api.httpClient.Do(req)
+	req, release, err := api.bind(req)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	defer release()
+
+	client := api.httpClient
+	if api.callTimeout > 0 {
+		// the call deadline replaces the client timeout
+		withoutTimeout := *api.httpClient
+		withoutTimeout.Timeout = 0
+		client = &withoutTimeout
+	}
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	api.stats.record(RequestStats{
+		Method:     req.Method,
+		Path:       req.URL.Path,
+		StatusCode: resp.StatusCode,
+		Duration:   time.Since(start),
+		Retries:    retries,
+		BytesRead:  int64(len(bodyBytes)),
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
+	api.rateLimit.record(resp.Header)
 
-	if api.debug {
-		bodyString := string(bodyBytes)
-		fmt.Printf("RESPONSE: \n%s\n", bodyString)
+	if api.debugging() {
+		api.logger.Printf("RESPONSE: \n%s\n", bodyBytes)
 	}
 
+	response := &Response{
+		Response: resp,
+		Links:    parseLinks(resp.Header.Get("Link")),
+	}
+	if total, err := strconv.Atoi(resp.Header.Get("X-Total")); err == nil {
+		response.Total = total
+	}
+	if resp.StatusCode >= 400 {
+		return response, &APIError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Body:       bodyBytes,
+		}
+	}
+	if raw, ok := v.(*[]byte); ok {
+		// binary answers, e.g. a .seb config, are handed back undecoded
+		*raw = bodyBytes
+		return response, nil
+	}
+	if v == nil || resp.StatusCode == http.StatusNoContent || len(bytes.TrimSpace(bodyBytes)) == 0 {
+		return response, nil
+	}
 	err = json.Unmarshal(bodyBytes, v)
 	// err = json.NewDecoder(resp.Body).Decode(v)
-	return err
+	return response, err
 }
 
-func random(min, max int64) int64 {
-	rand.Seed(time.Now().Unix())
-	return rand.Int63n(max-min) + min
+// maxNonce upper bound (exclusive) of the generated nonces
+var maxNonce = big.NewInt(10000000000000000)
+
+// newNonce returns an unpredictable nonce read from crypto/rand
+func newNonce() string {
+	n, err := rand.Int(rand.Reader, maxNonce)
+	if err != nil {
+		// crypto/rand only fails if the OS entropy source is broken
+		panic(err)
+	}
+	return n.String()
 }
 
 func getBaseParams() map[string]string {
 	ts := strconv.FormatUint(uint64(time.Now().UnixNano()/int64(time.Millisecond)), 10)
-	nonce := strconv.FormatUint(uint64(random(0, 10000000000000000)), 10)
+	nonce := newNonce()
 	return map[string]string{
 		"nonce":     nonce,
 		"timestamp": ts,
@@ -217,78 +710,163 @@ func getBaseParams() map[string]string {
 
 // Exams method
 func (api *API) Exams() ([]Exam, error) {
+	exams, _, err := api.ExamsWithResponse()
+	return exams, err
+}
+
+// ExamsWithResponse is Exams also returning the http response, e.g. to
+// inspect its headers
+func (api *API) ExamsWithResponse() ([]Exam, *Response, error) {
 	path := fmt.Sprintf("%s/exams", apiPrefix)
 	params := getBaseParams()
 	req, err := api.newGetRequest(path, params, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	type examsWrapper struct {
 		Items []Exam `json:"exams"`
 	}
 	var exams examsWrapper
-	err = api.do(req, &exams)
+	resp, err := api.send(req, &exams)
 
-	return exams.Items, err
+	return exams.Items, resp, err
+}
+
+// ExamsPaged GET /exams?page=&per_page=
+// returns a single page of exams, pages start at 1. The response carries the
+// pagination links and the total number of exams when the server sends them.
+func (api *API) ExamsPaged(page, perPage int) ([]Exam, *Response, error) {
+	path := fmt.Sprintf("%s/exams", apiPrefix)
+	params, queryParams := pageParams(getBaseParams(), page, perPage)
+	req, err := api.newGetRequest(path, params, queryParams)
+	if err != nil {
+		return nil, nil, err
+	}
+	return getPage[Exam](api, req, "exams")
+}
+
+// ExamsAll returns the exams of every page
+func (api *API) ExamsAll() ([]Exam, error) {
+	path := fmt.Sprintf("%s/exams", apiPrefix)
+	return getAll[Exam](api, path, "exams", nil)
+}
+
+// ExamsIter returns an iterator over the exams of every page
+func (api *API) ExamsIter() *Iterator[Exam] {
+	path := fmt.Sprintf("%s/exams", apiPrefix)
+	return newIterator[Exam](api, path, "exams", nil)
 }
 
 // Exam GET /exams/:id
 func (api *API) Exam(id int64) (Exam, error) {
+	exam, _, err := api.ExamWithResponse(id)
+	return exam, err
+}
+
+// ExamWithResponse is Exam also returning the http response
+func (api *API) ExamWithResponse(id int64) (Exam, *Response, error) {
 	path := fmt.Sprintf("%s/exams/%d", apiPrefix, id)
 	params := getBaseParams()
 	params["id"] = strconv.Itoa(int(id))
 	req, err := api.newGetRequest(path, params, nil)
 	if err != nil {
-		return Exam{}, err
+		return Exam{}, nil, err
 	}
 	type examWrapper struct {
 		Key Exam `json:"exam"`
 	}
 	var exam examWrapper
-	err = api.do(req, &exam)
+	resp, err := api.send(req, &exam)
 
-	return exam.Key, err
+	return exam.Key, resp, err
 }
 
 // Users GET /institutes/:institute_id/users
 func (api *API) Users(instituteID int64) ([]User, error) {
+	users, _, err := api.UsersWithResponse(instituteID)
+	return users, err
+}
+
+// UsersWithResponse is Users also returning the http response
+func (api *API) UsersWithResponse(instituteID int64) ([]User, *Response, error) {
 	path := fmt.Sprintf("%s/institutes/%d/users", apiPrefix, instituteID)
 	params := getBaseParams()
 	params["institute_id"] = strconv.Itoa(int(instituteID))
 	req, err := api.newGetRequest(path, params, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	type usersWrapper struct {
 		Items []User `json:"users"`
 	}
 	var users usersWrapper
-	err = api.do(req, &users)
+	resp, err := api.send(req, &users)
 
-	return users.Items, err
+	return users.Items, resp, err
+}
+
+// UsersPaged GET /institutes/:institute_id/users?page=&per_page=
+// returns a single page of users, pages start at 1. The response carries the
+// pagination links and the total number of users when the server sends them.
+func (api *API) UsersPaged(instituteID int64, page, perPage int) ([]User, *Response, error) {
+	path := fmt.Sprintf("%s/institutes/%d/users", apiPrefix, instituteID)
+	params := getBaseParams()
+	params["institute_id"] = strconv.Itoa(int(instituteID))
+	params, queryParams := pageParams(params, page, perPage)
+	req, err := api.newGetRequest(path, params, queryParams)
+	if err != nil {
+		return nil, nil, err
+	}
+	return getPage[User](api, req, "users")
+}
+
+// UsersAll returns the users of every page
+func (api *API) UsersAll(instituteID int64) ([]User, error) {
+	path := fmt.Sprintf("%s/institutes/%d/users", apiPrefix, instituteID)
+	params := map[string]string{"institute_id": strconv.Itoa(int(instituteID))}
+	return getAll[User](api, path, "users", params)
+}
+
+// UsersIter returns an iterator over the users of every page
+func (api *API) UsersIter(instituteID int64) *Iterator[User] {
+	path := fmt.Sprintf("%s/institutes/%d/users", apiPrefix, instituteID)
+	params := map[string]string{"institute_id": strconv.Itoa(int(instituteID))}
+	return newIterator[User](api, path, "users", params)
 }
 
 // ShowUser GET /institutes/:institute_id/users/:id
 func (api *API) ShowUser(instituteID, userID int64) (User, error) {
+	user, _, err := api.ShowUserWithResponse(instituteID, userID)
+	return user, err
+}
+
+// ShowUserWithResponse is ShowUser also returning the http response
+func (api *API) ShowUserWithResponse(instituteID, userID int64) (User, *Response, error) {
 	path := fmt.Sprintf("%s/institutes/%d/users/%d", apiPrefix, instituteID, userID)
 	params := getBaseParams()
 	params["id"] = strconv.Itoa(int(userID))
 	params["institute_id"] = strconv.Itoa(int(instituteID))
 	req, err := api.newGetRequest(path, params, nil)
 	if err != nil {
-		return User{}, err
+		return User{}, nil, err
 	}
 	type userWrapper struct {
 		Item User `json:"user"`
 	}
 	var user userWrapper
-	err = api.do(req, &user)
+	resp, err := api.send(req, &user)
 
-	return user.Item, err
+	return user.Item, resp, err
 }
 
 // ShowStudent GET /exams/:id/show_student?student_session_id=
 func (api *API) ShowStudent(examID, studentSessionID int64) (Student, error) {
+	student, _, err := api.ShowStudentWithResponse(examID, studentSessionID)
+	return student, err
+}
+
+// ShowStudentWithResponse is ShowStudent also returning the http response
+func (api *API) ShowStudentWithResponse(examID, studentSessionID int64) (Student, *Response, error) {
 	path := fmt.Sprintf("%s/exams/%d/show_student", apiPrefix, examID)
 	params := getBaseParams()
 	sessionID := strconv.Itoa(int(studentSessionID))
@@ -296,31 +874,64 @@ func (api *API) ShowStudent(examID, studentSessionID int64) (Student, error) {
 	params["id"] = strconv.Itoa(int(examID))
 	req, err := api.newGetRequest(path, params, map[string]string{"student_session_id": sessionID})
 	if err != nil {
-		return Student{}, err
+		return Student{}, nil, err
 	}
 	type studentWrapper struct {
 		Item Student `json:"student"`
 	}
 	var wrapper studentWrapper
-	err = api.do(req, &wrapper)
+	resp, err := api.send(req, &wrapper)
 
-	return wrapper.Item, err
+	return wrapper.Item, resp, err
 }
 
 // IndexStudents GET /exams/:id/index_students
+// returns the first page of students only on large exams, see
+// IndexStudentsPaged and IndexStudentsIter
 func (api *API) IndexStudents(examID int64) ([]Student, error) {
+	students, _, err := api.IndexStudentsWithResponse(examID)
+	return students, err
+}
+
+// IndexStudentsWithResponse is IndexStudents also returning the http
+// response, e.g. to read the total number of students
+func (api *API) IndexStudentsWithResponse(examID int64) ([]Student, *Response, error) {
 	path := fmt.Sprintf("%s/exams/%d/index_students", apiPrefix, examID)
 	params := getBaseParams()
 	params["id"] = strconv.Itoa(int(examID))
 	req, err := api.newGetRequest(path, params, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	type studentsWrapper struct {
 		Items []Student `json:"students"`
 	}
 	var students studentsWrapper
-	err = api.do(req, &students)
+	resp, err := api.send(req, &students)
+
+	return students.Items, resp, err
+}
+
+// IndexStudentsPaged GET /exams/:id/index_students?page=&per_page=
+// returns a single page of students, pages start at 1. The response carries
+// the pagination links and the total number of students when the server
+// sends them.
+func (api *API) IndexStudentsPaged(examID int64, page, perPage int) ([]Student, *Response, error) {
+	path := fmt.Sprintf("%s/exams/%d/index_students", apiPrefix, examID)
+	params := getBaseParams()
+	params["id"] = strconv.Itoa(int(examID))
+	params, queryParams := pageParams(params, page, perPage)
+	req, err := api.newGetRequest(path, params, queryParams)
+	if err != nil {
+		return nil, nil, err
+	}
+	return getPage[Student](api, req, "students")
+}
 
-	return students.Items, nil
+// IndexStudentsIter returns an iterator over the students of the exam, page
+// by page
+func (api *API) IndexStudentsIter(examID int64) *Iterator[Student] {
+	path := fmt.Sprintf("%s/exams/%d/index_students", apiPrefix, examID)
+	params := map[string]string{"id": strconv.Itoa(int(examID))}
+	return newIterator[Student](api, path, "students", params)
 }
@@ -1,12 +1,20 @@
 package proctorexam
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"log"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -31,7 +39,7 @@ func setup() func() {
 	url, _ := url.Parse(server.URL)
 	api, _ = New(BaseURL(url))
 	// for debugging requests and library
-	// api.debug = true
+	// api.logger, api.debug = log.New(os.Stdout, "", 0), true
 	return func() {
 		server.Close()
 	}
@@ -45,6 +53,23 @@ func fixture(path string) string {
 	return string(b)
 }
 
+// assertSigned checks the request signature covers the query params plus the
+// given path params
+func assertSigned(t *testing.T, r *http.Request, pathParams map[string]string) {
+	t.Helper()
+	query := r.URL.Query()
+	params := map[string]string{}
+	for key := range query {
+		if key != "signature" {
+			params[key] = query.Get(key)
+		}
+	}
+	for key, value := range pathParams {
+		params[key] = value
+	}
+	assert.Equal(t, api.signParams(params), query.Get("signature"))
+}
+
 // https://www.markphelps.me/testing-api-clients-in-go/
 func TestExams(t *testing.T) {
 	teardown := setup()
@@ -184,3 +209,683 @@ func TestIndexStudents(t *testing.T) {
 	assert.Equal(t, len(students), 1)
 	assert.Equal(t, int(students[0].ID), idStudent)
 }
+
+func TestSigningBaseString(t *testing.T) {
+	params := map[string]string{
+		"timestamp":          "1577836800000",
+		"nonce":              "4242",
+		"student_session_id": "4",
+		"id":                 "17",
+	}
+	golden := "id=17?nonce=4242?student_session_id=4?timestamp=1577836800000"
+
+	assert.Equal(t, golden, SigningBaseString(params))
+	assert.Equal(t, "", SigningBaseString(map[string]string{}))
+	assert.Equal(t, "nonce=1", SigningBaseString(map[string]string{"nonce": "1"}))
+}
+
+func TestNewRequiresCredentials(t *testing.T) {
+	_, err := New()
+	assert.Equal(t, ErrMissingCredentials, err)
+
+	_, err = New(APIKey("key"))
+	assert.Equal(t, ErrMissingCredentials, err)
+
+	_, err = New(APIKey(""), SecretKey("secret"))
+	assert.Equal(t, ErrMissingCredentials, err)
+
+	client, err := New(APIKey("key"), SecretKey("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, apiURL, client.baseURL.String())
+}
+
+func TestNoBaseURL(t *testing.T) {
+	client, err := New(BaseURL(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotPanics(t, func() {
+		_, err = client.Exams()
+	})
+	assert.Equal(t, ErrNoBaseURL, err)
+}
+
+func TestCredentials(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	url, _ := url.Parse(server.URL)
+	client, err := New(BaseURL(url), Credentials("key", "secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mux.HandleFunc("/api/v3/exams", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Token token=key", r.Header.Get("Authorization"))
+
+		params := map[string]string{
+			"nonce":     r.URL.Query().Get("nonce"),
+			"timestamp": r.URL.Query().Get("timestamp"),
+		}
+		assert.Equal(t, client.signParams(params), r.URL.Query().Get("signature"))
+		assert.NotEqual(t, api.signParams(params), r.URL.Query().Get("signature"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"exams": []}`)
+	})
+
+	_, err = client.Exams()
+	assert.NoError(t, err)
+}
+
+func TestWithContext(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v3/exams", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(2 * time.Second):
+		case <-r.Context().Done():
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"exams": []}`)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := api.WithContext(ctx).Exams()
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "unexpected error: %v", err)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Nil(t, api.ctx)
+}
+
+func TestBaseParamsUniqueNonces(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 10000; i++ {
+		nonce := getBaseParams()["nonce"]
+		if seen[nonce] {
+			t.Fatalf("duplicated nonce %s after %d calls", nonce, i)
+		}
+		seen[nonce] = true
+	}
+}
+
+type recordingTransport struct {
+	requests []*http.Request
+}
+
+func (rt *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.requests = append(rt.requests, req)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestHTTPClient(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v3/exams", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"exams": []}`)
+	})
+
+	transport := &recordingTransport{}
+	httpClient := &http.Client{Transport: transport, Timeout: time.Minute}
+	url, _ := url.Parse(server.URL)
+	client, err := New(BaseURL(url), HTTPClient(httpClient), Timeout(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.Exams()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(transport.requests))
+	assert.Equal(t, "/api/v3/exams", transport.requests[0].URL.Path)
+	assert.Equal(t, time.Minute, client.httpClient.Timeout)
+}
+
+func TestTimeout(t *testing.T) {
+	url, _ := url.Parse("http://localhost")
+	client, err := New(BaseURL(url), Timeout(5*time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 5*time.Second, client.httpClient.Timeout)
+
+	client, err = New(BaseURL(url), Timeout(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, time.Duration(0), client.httpClient.Timeout)
+
+	_, err = New(BaseURL(url), Timeout(-time.Second))
+	assert.Error(t, err)
+
+	_, err = New(BaseURL(url), HTTPClient(nil))
+	assert.Error(t, err)
+}
+
+func TestWithTimeout(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/index_students", idExam), func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(200 * time.Millisecond):
+		case <-r.Context().Done():
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"students": []}`)
+	})
+
+	_, err := api.WithTimeout(20 * time.Millisecond).IndexStudents(idExam)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	// the default timeout is untouched
+	_, err = api.IndexStudents(idExam)
+	assert.NoError(t, err)
+
+	// and the call timeout overrides a shorter client timeout
+	url, _ := url.Parse(server.URL)
+	client, err := New(BaseURL(url), Timeout(20*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.WithTimeout(time.Second).IndexStudents(idExam)
+	assert.NoError(t, err)
+}
+
+func TestUserAgent(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	var userAgents []string
+	mux.HandleFunc("/api/v3/exams", func(w http.ResponseWriter, r *http.Request) {
+		userAgents = append(userAgents, r.Header.Get("User-Agent"))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"exams": []}`)
+	})
+
+	_, err := api.Exams()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	url, _ := url.Parse(server.URL)
+	client, _ := New(BaseURL(url), UserAgent("acme-lms/2.1"))
+	_, err = client.Exams()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"proctorexam-go/" + Version, "acme-lms/2.1"}, userAgents)
+}
+
+func TestQueryParamsEncoding(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	value := "a&b=c d+e?f/g"
+	mux.HandleFunc("/api/v3/exams", func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasPrefix(r.URL.RawQuery, "nonce="))
+		query := r.URL.Query()
+		assert.Equal(t, value, query.Get("filter"))
+		assert.Equal(t, 4, len(query))
+
+		params := map[string]string{
+			"nonce":     query.Get("nonce"),
+			"timestamp": query.Get("timestamp"),
+			"filter":    value,
+		}
+		assert.Equal(t, api.signParams(params), query.Get("signature"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"exams": []}`)
+	})
+
+	params := getBaseParams()
+	params["filter"] = value
+	req, err := api.newGetRequest("/api/v3/exams", params, map[string]string{"filter": value})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, api.do(req, &struct{}{}))
+}
+
+func TestCloseCancelsInFlightRequests(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v3/exams", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(2 * time.Second):
+		case <-r.Context().Done():
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"exams": []}`)
+	})
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		api.Close()
+	}()
+
+	start := time.Now()
+	_, err := api.Exams()
+	assert.True(t, errors.Is(err, context.Canceled), "unexpected error: %v", err)
+	assert.Less(t, time.Since(start), time.Second)
+
+	_, err = api.Exams()
+	assert.Equal(t, ErrClientClosed, err)
+}
+
+func TestSignParams(t *testing.T) {
+	tests := []struct {
+		secret    string
+		params    map[string]string
+		signature string
+	}{
+		{
+			secret:    "secret",
+			params:    map[string]string{"nonce": "4242", "timestamp": "1577836800000"},
+			signature: "879bb58b8e04a6008c11431c3c9ab66f400e1aade51f6eebc6fd97f6ae1c1ffd",
+		},
+		{
+			secret:    "secret",
+			params:    map[string]string{"nonce": "4242", "timestamp": "1577836800000", "id": "17"},
+			signature: "bc8c973955f7a06f04f78605dae7cdf1ff1ce26b57d0800bd882e19f8532b3bc",
+		},
+		{
+			secret:    "s3cr3t",
+			params:    map[string]string{"nonce": "987654321", "timestamp": "1583139600000", "id": "17", "student_session_id": "4"},
+			signature: "10f34a412c448c8ec224a352b72b82a8f2521f1aded05acf373e338bf33b7e49",
+		},
+		{
+			secret:    "s3cr3t",
+			params:    map[string]string{"nonce": "1", "timestamp": "2", "email": "jane+retake@example.com", "institute_id": "17"},
+			signature: "0feaa265cfc03091991739d9d1f736ead442005b5be0b5aa05956c5163f752f7",
+		},
+		{
+			secret:    "",
+			params:    map[string]string{"nonce": "4242", "timestamp": "1577836800000"},
+			signature: "d9a7fe7cfa4ee2a789ecf72459beccc583ffebc583690232d71e8b85adb7bc71",
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.signature, SignParams(tt.secret, tt.params), SigningBaseString(tt.params))
+	}
+}
+
+type idleClosingTransport struct {
+	http.RoundTripper
+	closed int
+}
+
+func (it *idleClosingTransport) CloseIdleConnections() {
+	it.closed++
+}
+
+func TestCloseLeavesCustomClientConnections(t *testing.T) {
+	transport := &idleClosingTransport{RoundTripper: http.DefaultTransport}
+	url, _ := url.Parse("http://localhost")
+	client, _ := New(BaseURL(url), HTTPClient(&http.Client{Transport: transport}))
+
+	assert.NoError(t, client.Close())
+	assert.Equal(t, 0, transport.closed)
+}
+
+func TestSelfVerify(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v3/exams", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"exams": []}`)
+	})
+
+	url, _ := url.Parse(server.URL)
+	client, _ := New(BaseURL(url), SelfVerify(true))
+
+	value := "a&b=c d+e"
+	params := getBaseParams()
+	params["filter"] = value
+	req, err := client.newGetRequest("/api/v3/exams", params, map[string]string{"filter": value})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, client.do(req, &struct{}{}))
+
+	// the query the client used to build by pasting values verbatim
+	signature := client.signParams(params)
+	naive := fmt.Sprintf("nonce=%s&timestamp=%s&signature=%s&filter=%s",
+		params["nonce"], params["timestamp"], signature, value)
+	assert.ErrorIs(t, client.verifyQuery(naive, params), ErrSignatureMismatch)
+
+	// a request sending another value than the signed one is caught before
+	// it is sent, only when the option is set
+	calls := 0
+	mux.HandleFunc("/api/v3/users", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	for _, verify := range []bool{true, false} {
+		client, _ := New(BaseURL(url), SelfVerify(verify))
+		params := getBaseParams()
+		params["role"] = "proctor"
+		req, err := client.newGetRequest("/api/v3/users", params, map[string]string{"role": "admin"})
+		if verify {
+			assert.ErrorIs(t, err, ErrSignatureMismatch)
+			continue
+		}
+		if assert.NoError(t, err) {
+			var apiErr *APIError
+			assert.ErrorAs(t, client.do(req, nil), &apiErr)
+		}
+	}
+	assert.Equal(t, 1, calls)
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("broken body")
+}
+
+func TestLogger(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v3/exams", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"exams": [{"id": 17}]}`)
+	})
+
+	var buf bytes.Buffer
+	url, _ := url.Parse(server.URL)
+	client, _ := New(BaseURL(url), Logger(log.New(&buf, "", 0)))
+
+	_, err := client.Exams()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Contains(t, buf.String(), "GET /api/v3/exams?nonce=")
+	assert.Contains(t, buf.String(), `RESPONSE: 
+{"exams": [{"id": 17}]}`)
+
+	buf.Reset()
+	req, _ := http.NewRequest("POST", server.URL+"/api/v3/exams", failingReader{})
+	assert.NotPanics(t, func() { client.logRequest(req) })
+	assert.Contains(t, buf.String(), "could not dump request")
+}
+
+func TestDebugWithoutLoggerIsSilent(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	api.debug = true
+	assert.False(t, api.debugging())
+}
+
+func TestConcurrentRequests(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v3/exams", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Limit", "600")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"exams": [{"id": 17}]}`)
+	})
+	mux.HandleFunc(fmt.Sprintf("/api/v3/institutes/%d/users", idInst), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"users": [{"id": 11}]}`)
+	})
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/show_student", idExam), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"student": {"id": %d}}`, idStudent)
+	})
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		errs   []error
+		record = func(err error) {
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}
+	)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			switch i % 3 {
+			case 0:
+				_, err := api.Exams()
+				record(err)
+			case 1:
+				_, err := api.Users(idInst)
+				record(err)
+			default:
+				_, err := api.ShowStudent(idExam, idStudSession)
+				record(err)
+			}
+			api.LastRequestStats()
+			api.LastRateLimit()
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Empty(t, errs)
+}
+
+func TestExamWithResponse(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d", idExam), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v42"`)
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"exam": {"id": %d, "name": "Maths"}}`, idExam)
+	})
+
+	exam, resp, err := api.ExamWithResponse(idExam)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "Maths", exam.Name)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `"v42"`, resp.Header.Get("ETag"))
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+}
+
+func TestIndexStudentsWithResponse(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/index_students", idExam), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Total", "120")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"students": [{"id": 804}]}`)
+	})
+	mux.HandleFunc(fmt.Sprintf("/api/v3/institutes/%d/users/%d", idInst, idUser), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"u7"`)
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"user": {"id": %d}}`, idUser)
+	})
+
+	students, resp, err := api.IndexStudentsWithResponse(idExam)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(students))
+	assert.Equal(t, 120, resp.Total)
+
+	user, resp, err := api.ShowUserWithResponse(idInst, idUser)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(idUser), user.ID)
+	assert.Equal(t, `"u7"`, resp.Header.Get("ETag"))
+}
+
+func TestAPIVersion(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	url, _ := url.Parse(server.URL)
+	client, err := New(BaseURL(url), APIVersion("v4"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mux.HandleFunc("/api/v4/exams", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/vnd.procwise.v4", r.Header.Get("Accept"))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"exams": []}`)
+	})
+	mux.HandleFunc("/api/v3/exams", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/vnd.procwise.v3", r.Header.Get("Accept"))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"exams": []}`)
+	})
+
+	_, err = client.Exams()
+	assert.NoError(t, err)
+	_, err = api.Exams()
+	assert.NoError(t, err)
+
+	_, err = New(APIVersion(""))
+	assert.Error(t, err)
+}
+
+func TestMaxIdleConnsPerHost(t *testing.T) {
+	client, err := New(Credentials("key", "secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	transport := client.httpClient.Transport.(*http.Transport)
+	assert.Equal(t, defaultMaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+
+	client, err = New(Credentials("key", "secret"), MaxIdleConnsPerHost(64))
+	if err != nil {
+		t.Fatal(err)
+	}
+	transport = client.httpClient.Transport.(*http.Transport)
+	assert.Equal(t, 64, transport.MaxIdleConnsPerHost)
+
+	_, err = New(Credentials("key", "secret"), MaxIdleConnsPerHost(0))
+	assert.Error(t, err)
+}
+
+func BenchmarkShowStudent(b *testing.B) {
+	var (
+		mu    sync.Mutex
+		conns int
+	)
+	handler := http.NewServeMux()
+	handler.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/show_student", idExam), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"student": {"id": %d}}`, idStudent)
+	})
+	ts := httptest.NewUnstartedServer(handler)
+	ts.Config.ConnState = func(c net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			mu.Lock()
+			conns++
+			mu.Unlock()
+		}
+	}
+	ts.Start()
+	defer ts.Close()
+
+	url, _ := url.Parse(ts.URL)
+	client, err := New(BaseURL(url))
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer client.Close()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := client.ShowStudent(idExam, idStudSession); err != nil {
+				b.Error(err)
+			}
+		}
+	})
+	b.StopTimer()
+
+	// with connection reuse this stays close to GOMAXPROCS rather than b.N
+	mu.Lock()
+	b.ReportMetric(float64(conns), "conns")
+	mu.Unlock()
+}
+
+func TestBuildRequest(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	req, err := api.BuildRequest("GET", "/exams/17/show_student",
+		map[string]string{"id": "17"}, map[string]string{"student_session_id": "4"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "/api/v3/exams/17/show_student", req.URL.Path)
+	keys := []string{}
+	for _, pair := range strings.Split(req.URL.RawQuery, "&") {
+		keys = append(keys, strings.SplitN(pair, "=", 2)[0])
+	}
+	assert.Equal(t, []string{"nonce", "timestamp", "signature", "student_session_id"}, keys)
+	assertSigned(t, req, map[string]string{"id": "17"})
+}
+
+func TestNoContent(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d", idExam), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/api/v3/exams", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	exam, err := api.Exam(idExam)
+	assert.NoError(t, err)
+	assert.Equal(t, Exam{}, exam)
+
+	exams, err := api.Exams()
+	assert.NoError(t, err)
+	assert.Empty(t, exams)
+}
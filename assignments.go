@@ -0,0 +1,86 @@
+package proctorexam
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ExamReviewers GET /exams/:id/reviewers
+func (api *API) ExamReviewers(examID int64) ([]User, error) {
+	return api.examStaff(examID, "reviewers")
+}
+
+// AssignReviewer POST /exams/:id/reviewers?user_id=
+func (api *API) AssignReviewer(examID, userID int64) error {
+	return api.assignStaff(examID, userID, "reviewers")
+}
+
+// RemoveReviewer DELETE /exams/:id/reviewers/:user_id
+func (api *API) RemoveReviewer(examID, userID int64) error {
+	return api.removeStaff(examID, userID, "reviewers")
+}
+
+// ExamProctors GET /exams/:id/proctors
+// returns the proctors monitoring a live exam
+func (api *API) ExamProctors(examID int64) ([]User, error) {
+	return api.examStaff(examID, "proctors")
+}
+
+// AssignProctor POST /exams/:id/proctors?user_id=
+func (api *API) AssignProctor(examID, userID int64) error {
+	return api.assignStaff(examID, userID, "proctors")
+}
+
+// RemoveProctor DELETE /exams/:id/proctors/:user_id
+func (api *API) RemoveProctor(examID, userID int64) error {
+	return api.removeStaff(examID, userID, "proctors")
+}
+
+// examStaff lists the users assigned to the exam under the given collection
+func (api *API) examStaff(examID int64, collection string) ([]User, error) {
+	path := fmt.Sprintf("%s/exams/%d/%s", apiPrefix, examID, collection)
+	params := getBaseParams()
+	params["id"] = strconv.Itoa(int(examID))
+	req, err := api.newGetRequest(path, params, nil)
+	if err != nil {
+		return nil, err
+	}
+	type usersWrapper struct {
+		Items []User `json:"users"`
+	}
+	var users usersWrapper
+	if err = api.do(req, &users); err != nil {
+		return nil, examError(err)
+	}
+
+	return users.Items, nil
+}
+
+// assignStaff adds the user to the collection of the exam
+func (api *API) assignStaff(examID, userID int64, collection string) error {
+	path := fmt.Sprintf("%s/exams/%d/%s", apiPrefix, examID, collection)
+	params := getBaseParams()
+	user := strconv.Itoa(int(userID))
+	params["id"] = strconv.Itoa(int(examID))
+	params["user_id"] = user
+	req, err := api.newPostRequest(path, nil, params, map[string]string{"user_id": user})
+	if err != nil {
+		return err
+	}
+
+	return examError(api.do(req, nil))
+}
+
+// removeStaff removes the user from the collection of the exam
+func (api *API) removeStaff(examID, userID int64, collection string) error {
+	path := fmt.Sprintf("%s/exams/%d/%s/%d", apiPrefix, examID, collection, userID)
+	params := getBaseParams()
+	params["id"] = strconv.Itoa(int(examID))
+	params["user_id"] = strconv.Itoa(int(userID))
+	req, err := api.newDeleteRequest(path, params, nil)
+	if err != nil {
+		return err
+	}
+
+	return examError(api.do(req, nil))
+}
@@ -0,0 +1,95 @@
+package proctorexam
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExamReviewers(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	reviewers := map[string]bool{"13": true}
+	path := fmt.Sprintf("/api/v3/exams/%d/reviewers", idExam)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r, map[string]string{"id": fmt.Sprint(idExam)})
+		switch r.Method {
+		case "GET":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `{"users": [`)
+			sep := ""
+			for id := range reviewers {
+				fmt.Fprintf(w, `%s{"id": %s, "role": "reviewer"}`, sep, id)
+				sep = ","
+			}
+			fmt.Fprint(w, `]}`)
+		case "POST":
+			reviewers[r.URL.Query().Get("user_id")] = true
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	mux.HandleFunc(path+"/13", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		assertSigned(t, r, map[string]string{"id": fmt.Sprint(idExam), "user_id": "13"})
+		delete(reviewers, "13")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	users, err := api.ExamReviewers(idExam)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(users))
+
+	assert.NoError(t, api.AssignReviewer(idExam, 15))
+	assert.True(t, reviewers["15"])
+
+	assert.NoError(t, api.RemoveReviewer(idExam, 13))
+	users, err = api.ExamReviewers(idExam)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(users))
+	assert.Equal(t, int64(15), users[0].ID)
+
+	assert.ErrorIs(t, api.RemoveReviewer(99, 13), ErrExamNotFound)
+}
+
+func TestExamProctors(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	var methods []string
+	path := fmt.Sprintf("/api/v3/exams/%d/proctors", idExam)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		if r.Method == "POST" {
+			assert.Equal(t, "12", r.URL.Query().Get("user_id"))
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"users": [{"id": 12, "role": "proctor"}]}`)
+	})
+	mux.HandleFunc(path+"/12", func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	assert.NoError(t, api.AssignProctor(idExam, 12))
+	proctors, err := api.ExamProctors(idExam)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, RoleProctor, proctors[0].Role)
+	assert.NoError(t, api.RemoveProctor(idExam, 12))
+
+	assert.Equal(t, []string{"POST", "GET", "DELETE"}, methods)
+
+	assert.ErrorIs(t, api.RemoveProctor(99, 12), ErrExamNotFound)
+}
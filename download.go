@@ -0,0 +1,186 @@
+package proctorexam
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Recording video captured during a student session
+type Recording struct {
+	// Kind "webcam", "screen" or "mobile"
+	Kind string `json:"kind"`
+	URL  string `json:"url"`
+}
+
+// SessionRecordings GET /exams/:id/student_recordings?student_session_id=
+// returns the recordings of a finished session, to be fetched with
+// DownloadRecording
+func (api *API) SessionRecordings(examID, studentSessionID int64) ([]Recording, error) {
+	path := fmt.Sprintf("%s/exams/%d/student_recordings", apiPrefix, examID)
+	params := getBaseParams()
+	sessionID := strconv.Itoa(int(studentSessionID))
+	params["student_session_id"] = sessionID
+	params["id"] = strconv.Itoa(int(examID))
+	req, err := api.newGetRequest(path, params, map[string]string{"student_session_id": sessionID})
+	if err != nil {
+		return nil, err
+	}
+	type recordingsWrapper struct {
+		Items []Recording `json:"recordings"`
+	}
+	var recordings recordingsWrapper
+	if err = api.do(req, &recordings); err != nil {
+		return nil, sessionError(err)
+	}
+
+	return recordings.Items, nil
+}
+
+// Screenshot periodic capture taken during a student session
+type Screenshot struct {
+	ID      int64     `json:"id"`
+	TakenAt time.Time `json:"taken_at"`
+	// Source "camera" or "screen"
+	Source string `json:"source"`
+	URL    string `json:"url"`
+}
+
+// SessionScreenshots GET /exams/:id/student_screenshots?student_session_id=
+// returns the screenshots of a session in the order they were taken
+func (api *API) SessionScreenshots(examID, studentSessionID int64) ([]Screenshot, error) {
+	path := fmt.Sprintf("%s/exams/%d/student_screenshots", apiPrefix, examID)
+	params := getBaseParams()
+	sessionID := strconv.Itoa(int(studentSessionID))
+	params["student_session_id"] = sessionID
+	params["id"] = strconv.Itoa(int(examID))
+	req, err := api.newGetRequest(path, params, map[string]string{"student_session_id": sessionID})
+	if err != nil {
+		return nil, err
+	}
+	type screenshotsWrapper struct {
+		Items []Screenshot `json:"screenshots"`
+	}
+	var screenshots screenshotsWrapper
+	if err = api.do(req, &screenshots); err != nil {
+		return nil, sessionError(err)
+	}
+
+	return screenshots.Items, nil
+}
+
+// DownloadScreenshot streams the image of the screenshot into w
+func (api *API) DownloadScreenshot(ctx context.Context, screenshot Screenshot, w io.Writer) (int64, error) {
+	return api.DownloadRecording(ctx, screenshot.URL, w)
+}
+
+// DownloadRecording streams the file at rawURL into w without buffering it,
+// until done or ctx is cancelled, and returns the number of bytes written.
+// Use Download to resume an interrupted transfer into a file.
+func (api *API) DownloadRecording(ctx context.Context, rawURL string, w io.Writer) (int64, error) {
+	resp, release, err := api.WithContext(ctx).openDownload(rawURL, 0)
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, downloadError(resp)
+	}
+
+	return io.Copy(w, resp.Body)
+}
+
+// Download streams the file at rawURL (e.g. a recording URL returned by the
+// API) into w, requesting the bytes from offset on with a Range header. When
+// the server answers with the whole file instead of a 206, w is rewound and
+// the file is downloaded again from the start. An offset equal to the file
+// size is a completed download; any other unsatisfiable offset is an error.
+// It returns the size of the file written so far.
+//
+// The request is not signed: download URLs are pre-authorized and the API
+// token must not leak to the storage host. The client timeout does not apply,
+// as large recordings take longer to transfer; use WithContext to bound it.
+func (api *API) Download(rawURL string, w io.WriteSeeker, offset int64) (int64, error) {
+	resp, release, err := api.openDownload(rawURL, offset)
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+	case http.StatusOK:
+		// the range was ignored, start over
+		offset = 0
+	case http.StatusRequestedRangeNotSatisfiable:
+		// nothing left to fetch only if the offset is the end of the file
+		if size, ok := contentRangeSize(resp.Header.Get("Content-Range")); ok && size == offset {
+			return offset, nil
+		}
+		return 0, fmt.Errorf("proctorexam: offset %d outside of the file: %w", offset, downloadError(resp))
+	default:
+		return 0, downloadError(resp)
+	}
+
+	if _, err := w.Seek(offset, io.SeekStart); err != nil {
+		return 0, err
+	}
+	n, err := io.Copy(w, resp.Body)
+
+	return offset + n, err
+}
+
+// contentRangeSize returns the complete length of a Content-Range header such
+// as "bytes */1024", reporting false when it is absent or unknown
+func contentRangeSize(header string) (int64, bool) {
+	_, total, found := strings.Cut(header, "/")
+	if !found || !strings.HasPrefix(header, "bytes ") {
+		return 0, false
+	}
+	size, err := strconv.ParseInt(total, 10, 64)
+	return size, err == nil
+}
+
+// openDownload sends the unsigned GET request of a download, from offset when
+// positive. The returned func must be called once the body has been consumed.
+func (api *API) openDownload(rawURL string, offset int64) (*http.Response, func(), error) {
+	req, err := http.NewRequestWithContext(api.context(), "GET", rawURL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("User-Agent", api.userAgent)
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	req, release, err := api.bind(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client := *api.httpClient
+	client.Timeout = 0
+	resp, err := client.Do(req)
+	if err != nil {
+		release()
+		return nil, nil, err
+	}
+
+	return resp, release, nil
+}
+
+// downloadError reports an unexpected answer of the storage host
+func downloadError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return &APIError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Body:       body,
+	}
+}
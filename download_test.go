@@ -0,0 +1,201 @@
+package proctorexam
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var recording = []byte(strings.Repeat("proctorexam-recording-", 64))
+
+// partialFile returns a file holding the first n bytes of recording
+func partialFile(t *testing.T, n int) *os.File {
+	f, err := os.Create(filepath.Join(t.TempDir(), "recording.webm"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write(recording[:n]); err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestDownloadResumesWithRange(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	var ranges []string
+	mux.HandleFunc("/recordings/4.webm", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			ranges = append(ranges, r.Header.Get("Range"))
+		}
+		http.ServeContent(w, r, "4.webm", time.Time{}, bytes.NewReader(recording))
+	})
+
+	f := partialFile(t, 100)
+	defer f.Close()
+
+	size, err := api.Download(server.URL+"/recordings/4.webm", f, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"bytes=100-"}, ranges)
+	assert.Equal(t, int64(len(recording)), size)
+	got, _ := os.ReadFile(f.Name())
+	assert.Equal(t, recording, got)
+}
+
+func TestDownloadRestartsWithoutRangeSupport(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	var ranges []string
+	mux.HandleFunc("/recordings/4.webm", func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		w.Header().Set("Content-Length", fmt.Sprint(len(recording)))
+		w.WriteHeader(http.StatusOK)
+		w.Write(recording)
+	})
+
+	f := partialFile(t, 100)
+	defer f.Close()
+
+	size, err := api.Download(server.URL+"/recordings/4.webm", f, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"bytes=100-"}, ranges)
+	assert.Equal(t, int64(len(recording)), size)
+	got, _ := os.ReadFile(f.Name())
+	assert.Equal(t, recording, got)
+}
+
+func TestDownloadPastEnd(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/recordings/4.webm", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "4.webm", time.Time{}, bytes.NewReader(recording))
+	})
+
+	f := partialFile(t, len(recording))
+	defer f.Close()
+
+	// the file was already complete
+	size, err := api.Download(server.URL+"/recordings/4.webm", f, int64(len(recording)))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(recording)), size)
+
+	_, err = api.Download(server.URL+"/recordings/4.webm", f, int64(len(recording))+10)
+	var apiErr *APIError
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.Equal(t, http.StatusRequestedRangeNotSatisfiable, apiErr.StatusCode)
+	}
+}
+
+func TestDownloadOutlastsClientTimeout(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/recordings/4.webm", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", fmt.Sprint(len(recording)))
+		w.WriteHeader(http.StatusOK)
+		half := len(recording) / 2
+		w.Write(recording[:half])
+		w.(http.Flusher).Flush()
+		time.Sleep(150 * time.Millisecond)
+		w.Write(recording[half:])
+	})
+
+	url, _ := url.Parse(server.URL)
+	client, _ := New(BaseURL(url), Timeout(50*time.Millisecond))
+
+	f := partialFile(t, 0)
+	defer f.Close()
+
+	size, err := client.Download(server.URL+"/recordings/4.webm", f, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(len(recording)), size)
+}
+
+func TestSessionRecordings(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/student_recordings", idExam), func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "4", r.URL.Query().Get("student_session_id"))
+		assertSigned(t, r, map[string]string{"id": fmt.Sprint(idExam)})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"recordings": [{"kind": "webcam", "url": "%s/recordings/4.webm"}]}`, server.URL)
+	})
+	mux.HandleFunc("/recordings/4.webm", func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusOK)
+		w.Write(recording)
+	})
+
+	recordings, err := api.SessionRecordings(idExam, idStudSession)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "webcam", recordings[0].Kind)
+
+	var buf bytes.Buffer
+	n, err := api.DownloadRecording(context.Background(), recordings[0].URL, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(len(recording)), n)
+	assert.Equal(t, recording, buf.Bytes())
+
+	_, err = api.DownloadRecording(context.Background(), server.URL+"/recordings/missing.webm", &buf)
+	var apiErr *APIError
+	assert.ErrorAs(t, err, &apiErr)
+}
+
+func TestSessionScreenshots(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/student_screenshots", idExam), func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "4", r.URL.Query().Get("student_session_id"))
+		assertSigned(t, r, map[string]string{"id": fmt.Sprint(idExam)})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"screenshots": [
+			{"id": 1, "taken_at": "2026-06-12T09:01:00Z", "source": "camera", "url": "%[1]s/screenshots/1.jpg"},
+			{"id": 2, "taken_at": "2026-06-12T09:01:00Z", "source": "screen", "url": "%[1]s/screenshots/2.jpg"}
+		]}`, server.URL)
+	})
+	mux.HandleFunc("/screenshots/2.jpg", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "jpeg")
+	})
+
+	screenshots, err := api.SessionScreenshots(idExam, idStudSession)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, len(screenshots))
+	assert.Equal(t, "screen", screenshots[1].Source)
+	assert.Equal(t, time.Date(2026, 6, 12, 9, 1, 0, 0, time.UTC), screenshots[1].TakenAt)
+
+	var buf bytes.Buffer
+	_, err = api.DownloadScreenshot(context.Background(), screenshots[1], &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "jpeg", buf.String())
+}
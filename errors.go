@@ -0,0 +1,55 @@
+package proctorexam
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// APIError is returned when the API answers with a non-2xx status code
+type APIError struct {
+	StatusCode int
+	Status     string
+	Body       []byte
+}
+
+func (e *APIError) Error() string {
+	if len(e.Body) == 0 {
+		return fmt.Sprintf("proctorexam: %s", e.Status)
+	}
+	body := e.Body
+	if len(body) > 256 {
+		body = body[:256]
+	}
+	return fmt.Sprintf("proctorexam: %s: %s", e.Status, body)
+}
+
+// sessionError maps a 404 answer of a student session endpoint to
+// ErrSessionNotFound, keeping the underlying *APIError reachable
+func sessionError(err error) error {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %w", ErrSessionNotFound, err)
+	}
+	return err
+}
+
+// examError maps a 404 answer of an exam endpoint to ErrExamNotFound, keeping
+// the underlying *APIError reachable
+func examError(err error) error {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %w", ErrExamNotFound, err)
+	}
+	return err
+}
+
+// userError maps a 404 answer of a user endpoint to ErrUserNotFound, keeping
+// the underlying *APIError reachable
+func userError(err error) error {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %w", ErrUserNotFound, err)
+	}
+	return err
+}
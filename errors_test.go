@@ -0,0 +1,74 @@
+package proctorexam
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIError(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d", idExam), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, "<html><body>Not Found</body></html>")
+	})
+	mux.HandleFunc("/api/v3/exams", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, "Internal Server Error")
+	})
+
+	_, err := api.Exam(idExam)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %v", err)
+	}
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, "404 Not Found", apiErr.Status)
+	assert.Equal(t, "<html><body>Not Found</body></html>", string(apiErr.Body))
+
+	_, err = api.Exams()
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %v", err)
+	}
+	assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+	assert.Equal(t, "proctorexam: 500 Internal Server Error: Internal Server Error", err.Error())
+}
+
+func TestIndexStudentsAPIError(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/index_students", idExam), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	_, err := api.IndexStudents(idExam)
+	var apiErr *APIError
+	if assert.True(t, errors.As(err, &apiErr), "unexpected error: %v", err) {
+		assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+	}
+}
+
+func TestIndexStudentsMalformedJSON(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/index_students", idExam), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"students": [{"id": 804,`)
+	})
+
+	students, err := api.IndexStudents(idExam)
+	var syntaxErr *json.SyntaxError
+	assert.True(t, errors.As(err, &syntaxErr), "unexpected error: %v", err)
+	assert.Empty(t, students)
+}
@@ -0,0 +1,230 @@
+package proctorexam
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// reconnect delays of StreamSessionEvents after a dropped connection
+var (
+	streamMinBackoff = time.Second
+	streamMaxBackoff = 30 * time.Second
+)
+
+// maxStreamLine longest line StreamSessionEvents accepts from the event stream
+const maxStreamLine = 1 << 20
+
+// SessionEventType kind of a session event. Types unknown to this version of
+// the sdk are kept as sent by the server.
+type SessionEventType string
+
+// Documented session event types
+const (
+	EventStarted  SessionEventType = "started"
+	EventPaused   SessionEventType = "paused"
+	EventResumed  SessionEventType = "resumed"
+	EventFlagged  SessionEventType = "flagged"
+	EventFinished SessionEventType = "finished"
+)
+
+// SessionEvent state change of a student session
+type SessionEvent struct {
+	// ID of the event in the stream, used to resume after a reconnection
+	ID               string           `json:"-"`
+	Type             SessionEventType `json:"type"`
+	StudentSessionID int64            `json:"student_session_id"`
+	Status           StudentStatus    `json:"status"`
+	CreatedAt        time.Time        `json:"created_at"`
+}
+
+// droppedStreamError transient failure of the event stream, followed by a
+// reconnection
+type droppedStreamError struct {
+	err error
+}
+
+func (e *droppedStreamError) Error() string {
+	return e.err.Error()
+}
+
+func (e *droppedStreamError) Unwrap() error {
+	return e.err
+}
+
+// StreamSessionEvents GET /exams/:id/events (text/event-stream)
+// emits the live session events of the exam until ctx is cancelled. Dropped
+// connections are re-established with an exponential backoff, resuming after
+// the last received event. Errors that reconnecting can not fix end the
+// stream, such as a 4xx answer, a closed client, an event that fails to
+// decode or a line longer than 1MB. The error channel receives the error that
+// ended the stream, if any; both channels are closed once the stream is over.
+func (api *API) StreamSessionEvents(ctx context.Context, examID int64) (<-chan SessionEvent, <-chan error) {
+	events := make(chan SessionEvent)
+	errs := make(chan error, 1)
+
+	// the stream stays open well beyond the client timeout
+	client := *api.httpClient
+	client.Timeout = 0
+
+	go func() {
+		defer close(events)
+		defer close(errs)
+
+		lastID := ""
+		backoff := streamMinBackoff
+		for {
+			received, err := api.streamOnce(ctx, &client, examID, &lastID, events)
+			if ctx.Err() != nil {
+				return
+			}
+			var dropped *droppedStreamError
+			if err != nil && !errors.As(err, &dropped) {
+				errs <- err
+				return
+			}
+			if received {
+				backoff = streamMinBackoff
+			}
+			if err := api.wait(ctx, backoff); err != nil {
+				if ctx.Err() == nil {
+					errs <- err
+				}
+				return
+			}
+			if backoff *= 2; backoff > streamMaxBackoff {
+				backoff = streamMaxBackoff
+			}
+		}
+	}()
+
+	return events, errs
+}
+
+// streamOnce reads the event stream until it ends, reporting whether any event
+// was received
+func (api *API) streamOnce(ctx context.Context, client *http.Client, examID int64, lastID *string, events chan<- SessionEvent) (bool, error) {
+	path := fmt.Sprintf("%s/exams/%d/events", apiPrefix, examID)
+	params := getBaseParams()
+	params["id"] = strconv.Itoa(int(examID))
+	req, err := api.WithContext(ctx).newGetRequest(path, params, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if *lastID != "" {
+		req.Header.Set("Last-Event-ID", *lastID)
+	}
+	req, release, err := api.bind(req)
+	if err != nil {
+		return false, err
+	}
+	defer release()
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, &droppedStreamError{err}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		err := &APIError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Body:       body,
+		}
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			return false, &droppedStreamError{err}
+		}
+		return false, err
+	}
+
+	received := false
+	var id, name string
+	var data []string
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxStreamLine)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			// a blank line dispatches the event
+			if len(data) > 0 {
+				var event SessionEvent
+				if err := json.Unmarshal([]byte(strings.Join(data, "\n")), &event); err != nil {
+					return received, fmt.Errorf("proctorexam: decoding event %q: %w", id, err)
+				}
+				event.ID = id
+				if event.Type == "" {
+					event.Type = SessionEventType(name)
+				}
+				if id != "" {
+					*lastID = id
+				}
+				select {
+				case events <- event:
+					received = true
+				case <-ctx.Done():
+					return received, ctx.Err()
+				}
+			}
+			id, name, data = "", "", nil
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			id = value
+		case "event":
+			name = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		// reconnecting would resume at the same oversized line
+		if errors.Is(err, bufio.ErrTooLong) {
+			return received, fmt.Errorf("proctorexam: event stream: %w", err)
+		}
+		return received, &droppedStreamError{err}
+	}
+	return received, nil
+}
+
+// SessionEvents GET /exams/:id/student_events?student_session_id=
+// returns the event log of a student session in chronological order
+func (api *API) SessionEvents(examID, studentSessionID int64) ([]SessionEvent, error) {
+	path := fmt.Sprintf("%s/exams/%d/student_events", apiPrefix, examID)
+	params := getBaseParams()
+	sessionID := strconv.Itoa(int(studentSessionID))
+	params["student_session_id"] = sessionID
+	params["id"] = strconv.Itoa(int(examID))
+	req, err := api.newGetRequest(path, params, map[string]string{"student_session_id": sessionID})
+	if err != nil {
+		return nil, err
+	}
+	type eventsWrapper struct {
+		Items []SessionEvent `json:"events"`
+	}
+	var events eventsWrapper
+	if err = api.do(req, &events); err != nil {
+		return nil, sessionError(err)
+	}
+	sort.SliceStable(events.Items, func(i, j int) bool {
+		return events.Items[i].CreatedAt.Before(events.Items[j].CreatedAt)
+	})
+
+	return events.Items, nil
+}
@@ -0,0 +1,157 @@
+package proctorexam
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamSessionEvents(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	streamMinBackoff = time.Millisecond
+	defer func() { streamMinBackoff = time.Second }()
+
+	path := fmt.Sprintf("/api/v3/exams/%d/events", idExam)
+
+	var (
+		mu      sync.Mutex
+		lastIDs []string
+	)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "text/event-stream", r.Header.Get("Accept"))
+		mu.Lock()
+		lastIDs = append(lastIDs, r.Header.Get("Last-Event-ID"))
+		connections := len(lastIDs)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		if connections == 1 {
+			fmt.Fprint(w, ": keep-alive\n\n")
+			fmt.Fprint(w, "id: 1\nevent: started\ndata: {\"student_session_id\": 4, \"status\": \"started\", \"created_at\": \"2020-03-02T09:04:12Z\"}\n\n")
+			fmt.Fprint(w, "id: 2\ndata: {\"type\": \"flagged\",\ndata: \"student_session_id\": 4}\n\n")
+			w.(http.Flusher).Flush()
+			return
+		}
+		// the connection dropped, resume after the last event
+		fmt.Fprint(w, "id: 3\nevent: finished\ndata: {\"student_session_id\": 4, \"status\": \"finished\"}\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, errs := api.StreamSessionEvents(ctx, idExam)
+
+	var received []SessionEvent
+	for event := range events {
+		received = append(received, event)
+		if len(received) == 3 {
+			cancel()
+		}
+	}
+	assert.NoError(t, <-errs)
+
+	mu.Lock()
+	assert.Equal(t, []string{"", "2"}, lastIDs)
+	mu.Unlock()
+	assert.Equal(t, 3, len(received))
+	assert.Equal(t, "1", received[0].ID)
+	assert.Equal(t, EventStarted, received[0].Type)
+	assert.Equal(t, time.Date(2020, 3, 2, 9, 4, 12, 0, time.UTC), received[0].CreatedAt)
+	assert.Equal(t, EventFlagged, received[1].Type)
+	assert.Equal(t, int64(4), received[1].StudentSessionID)
+	assert.Equal(t, StudentStatusFinished, received[2].Status)
+	assert.True(t, received[2].Status.IsFinished())
+}
+
+func TestStreamSessionEventsFatalError(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/events", idExam), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	events, errs := api.StreamSessionEvents(context.Background(), idExam)
+	for range events {
+		t.Fatal("unexpected event")
+	}
+	err := <-errs
+	if apiErr, ok := err.(*APIError); assert.True(t, ok, "unexpected error: %v", err) {
+		assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+	}
+}
+
+func TestStreamSessionEventsBadFrame(t *testing.T) {
+	for name, frame := range map[string]string{
+		"malformed": "id: 1\ndata: {\"type\": \n\n",
+		"too long":  "id: 1\ndata: " + strings.Repeat("x", maxStreamLine) + "\n\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			teardown := setup()
+			defer teardown()
+
+			connections := 0
+			mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/events", idExam), func(w http.ResponseWriter, r *http.Request) {
+				connections++
+				w.Header().Set("Content-Type", "text/event-stream")
+				fmt.Fprint(w, frame)
+			})
+
+			events, errs := api.StreamSessionEvents(context.Background(), idExam)
+			for range events {
+				t.Fatal("unexpected event")
+			}
+			// the stream ends instead of reconnecting to the same frame
+			assert.Error(t, <-errs)
+			assert.Equal(t, 1, connections)
+		})
+	}
+}
+
+func TestStreamSessionEventsAfterClose(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	api.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	events, errs := api.StreamSessionEvents(ctx, idExam)
+	for range events {
+		t.Fatal("unexpected event")
+	}
+	assert.Equal(t, ErrClientClosed, <-errs)
+	assert.NoError(t, ctx.Err())
+}
+
+func TestSessionEvents(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/student_events", idExam), func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "4", r.URL.Query().Get("student_session_id"))
+		assertSigned(t, r, map[string]string{"id": fmt.Sprint(idExam)})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, fixture("session_events.json"))
+	})
+
+	events, err := api.SessionEvents(idExam, idStudSession)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 5, len(events))
+	assert.Equal(t, EventStarted, events[0].Type)
+	assert.Equal(t, EventFinished, events[4].Type)
+	assert.Equal(t, time.Date(2026, 6, 12, 9, 5, 12, 0, time.UTC), events[1].CreatedAt)
+}
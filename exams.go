@@ -0,0 +1,532 @@
+package proctorexam
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxParallelRequests bounds the number of concurrent requests issued by the
+// bulk helpers
+const maxParallelRequests = 4
+
+// ErrProctorRatioUnset is returned when an exam has no proctor-to-student
+// ratio configured, e.g. because it is not live proctored
+var ErrProctorRatioUnset = errors.New("proctorexam: proctor ratio not configured")
+
+// ErrReviewerRulesUnset is returned when reviewers of an exam are assigned
+// manually
+var ErrReviewerRulesUnset = errors.New("proctorexam: reviewer assignment rules not configured")
+
+// ErrExamNotFound is returned when the exam to change does not exist
+var ErrExamNotFound = errors.New("proctorexam: exam not found")
+
+// ErrInvalidTransition is returned when an exam can not move from its current
+// status to the requested one
+var ErrInvalidTransition = errors.New("proctorexam: invalid exam status transition")
+
+// ExamStatus lifecycle state of an exam. Values unknown to this version of the
+// sdk are kept as sent by the server.
+type ExamStatus string
+
+// Documented exam states
+const (
+	ExamStatusDraft  ExamStatus = "draft"
+	ExamStatusActive ExamStatus = "active"
+	ExamStatusClosed ExamStatus = "closed"
+)
+
+// examTransitions statuses each exam status can move to
+var examTransitions = map[ExamStatus][]ExamStatus{
+	ExamStatusDraft:  {ExamStatusActive},
+	ExamStatusActive: {ExamStatusClosed},
+	ExamStatusClosed: {ExamStatusActive},
+}
+
+// CanTransition reports whether an exam in status s can move to status to.
+// Unknown statuses are left to the server to validate.
+func (s ExamStatus) CanTransition(to ExamStatus) bool {
+	next, ok := examTransitions[s]
+	if !ok {
+		return true
+	}
+	for _, status := range next {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ReviewerRules how reviewers are automatically assigned to the sessions of
+// an exam
+type ReviewerRules struct {
+	// Strategy e.g. "round_robin" or "subject"
+	Strategy    string            `json:"strategy"`
+	ReviewerIDs []int64           `json:"reviewer_ids"`
+	Parameters  map[string]string `json:"parameters"`
+}
+
+// ExamInput exam created by CreateExam. A zero StartsAt or EndsAt leaves the
+// exam unscheduled.
+type ExamInput struct {
+	Name        string
+	InstituteID int64
+	StartsAt    time.Time
+	EndsAt      time.Time
+}
+
+// MarshalJSON encodes the scheduling times as RFC3339, omitting unset ones
+func (e ExamInput) MarshalJSON() ([]byte, error) {
+	aux := struct {
+		Name        string `json:"name"`
+		InstituteID int64  `json:"institute_id"`
+		StartsAt    string `json:"starts_at,omitempty"`
+		EndsAt      string `json:"ends_at,omitempty"`
+	}{Name: e.Name, InstituteID: e.InstituteID}
+	if !e.StartsAt.IsZero() {
+		aux.StartsAt = e.StartsAt.Format(time.RFC3339)
+	}
+	if !e.EndsAt.IsZero() {
+		aux.EndsAt = e.EndsAt.Format(time.RFC3339)
+	}
+	return json.Marshal(aux)
+}
+
+// ExamUpdate changes applied by UpdateExam. Nil fields are left untouched.
+type ExamUpdate struct {
+	Name     *string    `json:"name,omitempty"`
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+	EndsAt   *time.Time `json:"ends_at,omitempty"`
+	// settings, see ExamSettings
+	ProctoringType *string `json:"proctoring_type,omitempty"`
+	ProctorRatio   *int    `json:"max_students_per_proctor,omitempty"`
+}
+
+// ExamSettings configuration of an exam
+type ExamSettings struct {
+	ExamID         int64  `json:"exam_id"`
+	ProctoringType string `json:"proctoring_type"`
+	// ProctorRatio max number of students monitored by a single proctor
+	ProctorRatio int `json:"max_students_per_proctor"`
+	// ResultsReleased whether students can see their results
+	ResultsReleased   bool       `json:"results_released"`
+	ResultsReleasedAt *time.Time `json:"results_released_at"`
+	// ReviewerRules nil when reviewers are assigned manually
+	ReviewerRules *ReviewerRules `json:"reviewer_assignment"`
+	// AllowedResources e.g. "calculator" or "scratch_paper"
+	AllowedResources []string      `json:"allowed_resources"`
+	IdentityCheck    IdentityCheck `json:"identity_check"`
+}
+
+// IdentityCheck verifications the students go through before the exam
+type IdentityCheck struct {
+	IDCard    bool `json:"id_card"`
+	FacePhoto bool `json:"face_photo"`
+	RoomScan  bool `json:"room_scan"`
+}
+
+// ExamSettings GET /exams/:id/show_settings
+func (api *API) ExamSettings(id int64) (ExamSettings, error) {
+	path := fmt.Sprintf("%s/exams/%d/show_settings", apiPrefix, id)
+	params := getBaseParams()
+	params["id"] = strconv.Itoa(int(id))
+	req, err := api.newGetRequest(path, params, nil)
+	if err != nil {
+		return ExamSettings{}, err
+	}
+	type settingsWrapper struct {
+		Item ExamSettings `json:"settings"`
+	}
+	var wrapper settingsWrapper
+	err = api.do(req, &wrapper)
+
+	return wrapper.Item, err
+}
+
+// ExamSettingsByIDs fetches the settings of several exams concurrently. The
+// settings that could be fetched are returned even when some exams fail, in
+// which case the error joins every failure. Exams not fetched yet are skipped
+// once the client context is done.
+func (api *API) ExamSettingsByIDs(ids []int64) (map[int64]ExamSettings, error) {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+		sem  = make(chan struct{}, maxParallelRequests)
+	)
+	settings := make(map[int64]ExamSettings, len(ids))
+	for _, id := range ids {
+		wg.Add(1)
+		go func(id int64) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-api.context().Done():
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("exam %d: %w", id, api.context().Err()))
+				mu.Unlock()
+				return
+			}
+
+			s, err := api.ExamSettings(id)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("exam %d: %w", id, err))
+				return
+			}
+			settings[id] = s
+		}(id)
+	}
+	wg.Wait()
+
+	return settings, errors.Join(errs...)
+}
+
+// ExamProctorRatio returns the max number of students per proctor configured
+// for a live proctored exam
+func (api *API) ExamProctorRatio(id int64) (int, error) {
+	settings, err := api.ExamSettings(id)
+	if err != nil {
+		return 0, err
+	}
+	if settings.ProctorRatio <= 0 {
+		return 0, ErrProctorRatioUnset
+	}
+
+	return settings.ProctorRatio, nil
+}
+
+// ExamResultsReleased reports whether the results of the exam have been
+// released to the students and, if so, when
+func (api *API) ExamResultsReleased(id int64) (bool, *time.Time, error) {
+	settings, err := api.ExamSettings(id)
+	if err != nil {
+		return false, nil, err
+	}
+	if !settings.ResultsReleased {
+		return false, nil, nil
+	}
+
+	return true, settings.ResultsReleasedAt, nil
+}
+
+// ReleaseExamResults POST /exams/:id/release_results
+// makes the results visible to the students and returns the updated exam
+func (api *API) ReleaseExamResults(id int64) (Exam, error) {
+	path := fmt.Sprintf("%s/exams/%d/release_results", apiPrefix, id)
+	params := getBaseParams()
+	params["id"] = strconv.Itoa(int(id))
+	req, err := api.newPostRequest(path, nil, params, nil)
+	if err != nil {
+		return Exam{}, err
+	}
+	type examWrapper struct {
+		Key Exam `json:"exam"`
+	}
+	var exam examWrapper
+	err = api.do(req, &exam)
+
+	return exam.Key, err
+}
+
+// ExamReviewerRules returns the rules used to route the sessions of the exam
+// to reviewers
+func (api *API) ExamReviewerRules(examID int64) (ReviewerRules, error) {
+	settings, err := api.ExamSettings(examID)
+	if err != nil {
+		return ReviewerRules{}, err
+	}
+	if settings.ReviewerRules == nil {
+		return ReviewerRules{}, ErrReviewerRulesUnset
+	}
+
+	return *settings.ReviewerRules, nil
+}
+
+// UpdateExam PATCH /exams/:id
+func (api *API) UpdateExam(id int64, changes ExamUpdate) (Exam, error) {
+	path := fmt.Sprintf("%s/exams/%d", apiPrefix, id)
+	params := getBaseParams()
+	params["id"] = strconv.Itoa(int(id))
+	body := struct {
+		Exam ExamUpdate `json:"exam"`
+	}{changes}
+	req, err := api.newPatchRequest(path, body, params, nil)
+	if err != nil {
+		return Exam{}, err
+	}
+	type examWrapper struct {
+		Key Exam `json:"exam"`
+	}
+	var exam examWrapper
+	if err = api.do(req, &exam); err != nil {
+		return Exam{}, examError(err)
+	}
+
+	return exam.Key, nil
+}
+
+// DeleteExam DELETE /exams/:id
+// returns an error wrapping ErrExamNotFound when the exam does not exist
+func (api *API) DeleteExam(id int64) error {
+	path := fmt.Sprintf("%s/exams/%d", apiPrefix, id)
+	params := getBaseParams()
+	params["id"] = strconv.Itoa(int(id))
+	req, err := api.newDeleteRequest(path, params, nil)
+	if err != nil {
+		return err
+	}
+
+	return examError(api.do(req, nil))
+}
+
+// ExamsByInstitute GET /institutes/:institute_id/exams
+func (api *API) ExamsByInstitute(instituteID int64) ([]Exam, error) {
+	path := fmt.Sprintf("%s/institutes/%d/exams", apiPrefix, instituteID)
+	params := getBaseParams()
+	params["institute_id"] = strconv.Itoa(int(instituteID))
+	req, err := api.newGetRequest(path, params, nil)
+	if err != nil {
+		return nil, err
+	}
+	type examsWrapper struct {
+		Items []Exam `json:"exams"`
+	}
+	var exams examsWrapper
+	err = api.do(req, &exams)
+
+	return exams.Items, err
+}
+
+// CreateExam POST /exams?institute_id=
+// creates an exam and returns it with its server-assigned id
+func (api *API) CreateExam(input ExamInput) (Exam, error) {
+	path := fmt.Sprintf("%s/exams", apiPrefix)
+	params := getBaseParams()
+	instituteID := strconv.Itoa(int(input.InstituteID))
+	params["institute_id"] = instituteID
+	body := struct {
+		Exam ExamInput `json:"exam"`
+	}{input}
+	req, err := api.newPostRequest(path, body, params, map[string]string{"institute_id": instituteID})
+	if err != nil {
+		return Exam{}, err
+	}
+	type examWrapper struct {
+		Key Exam `json:"exam"`
+	}
+	var exam examWrapper
+	err = api.do(req, &exam)
+
+	return exam.Key, err
+}
+
+// DuplicateOption customizes the copy made by DuplicateExam
+type DuplicateOption func(*duplicateInput)
+
+type duplicateInput struct {
+	Name            string     `json:"name,omitempty"`
+	StartsAt        *time.Time `json:"starts_at,omitempty"`
+	EndsAt          *time.Time `json:"ends_at,omitempty"`
+	IncludeStudents bool       `json:"include_students"`
+}
+
+// DuplicateName names the copy, by default the server derives it from the
+// original name
+func DuplicateName(name string) DuplicateOption {
+	return func(d *duplicateInput) {
+		d.Name = name
+	}
+}
+
+// DuplicateWindow schedules the copy between start and end
+func DuplicateWindow(start, end time.Time) DuplicateOption {
+	return func(d *duplicateInput) {
+		d.StartsAt = &start
+		d.EndsAt = &end
+	}
+}
+
+// DuplicateStudents also enrolls the students of the original exam in the
+// copy
+func DuplicateStudents() DuplicateOption {
+	return func(d *duplicateInput) {
+		d.IncludeStudents = true
+	}
+}
+
+// DuplicateExam POST /exams/:id/duplicate
+// copies the configuration of the exam and returns the new exam
+func (api *API) DuplicateExam(id int64, opts ...DuplicateOption) (Exam, error) {
+	path := fmt.Sprintf("%s/exams/%d/duplicate", apiPrefix, id)
+	params := getBaseParams()
+	params["id"] = strconv.Itoa(int(id))
+	var input duplicateInput
+	for _, opt := range opts {
+		opt(&input)
+	}
+	body := struct {
+		Exam duplicateInput `json:"exam"`
+	}{input}
+	req, err := api.newPostRequest(path, body, params, nil)
+	if err != nil {
+		return Exam{}, err
+	}
+	type examWrapper struct {
+		Key Exam `json:"exam"`
+	}
+	var exam examWrapper
+	if err = api.do(req, &exam); err != nil {
+		return Exam{}, examError(err)
+	}
+
+	return exam.Key, nil
+}
+
+// ArchivedExams GET /exams?archived=true
+// returns the archived exams, which Exams leaves out
+func (api *API) ArchivedExams() ([]Exam, error) {
+	path := fmt.Sprintf("%s/exams", apiPrefix)
+	params := getBaseParams()
+	params["archived"] = "true"
+	req, err := api.newGetRequest(path, params, map[string]string{"archived": "true"})
+	if err != nil {
+		return nil, err
+	}
+	type examsWrapper struct {
+		Items []Exam `json:"exams"`
+	}
+	var exams examsWrapper
+	err = api.do(req, &exams)
+
+	return exams.Items, err
+}
+
+// ArchiveExam POST /exams/:id/archive
+func (api *API) ArchiveExam(id int64) (Exam, error) {
+	return api.examAction(id, "archive")
+}
+
+// UnarchiveExam POST /exams/:id/unarchive
+func (api *API) UnarchiveExam(id int64) (Exam, error) {
+	return api.examAction(id, "unarchive")
+}
+
+// examAction posts a state changing action of the exam, e.g. "archive"
+func (api *API) examAction(id int64, action string) (Exam, error) {
+	path := fmt.Sprintf("%s/exams/%d/%s", apiPrefix, id, action)
+	params := getBaseParams()
+	params["id"] = strconv.Itoa(int(id))
+	req, err := api.newPostRequest(path, nil, params, nil)
+	if err != nil {
+		return Exam{}, err
+	}
+	type examWrapper struct {
+		Key Exam `json:"exam"`
+	}
+	var exam examWrapper
+	if err = api.do(req, &exam); err != nil {
+		return Exam{}, examError(err)
+	}
+
+	return exam.Key, nil
+}
+
+// ActivateExam POST /exams/:id/activate
+// opens a draft or closed exam to the students. It returns an error wrapping
+// ErrInvalidTransition, without calling the action, when the exam is already
+// active.
+func (api *API) ActivateExam(id int64) (Exam, error) {
+	return api.transitionExam(id, ExamStatusActive, "activate")
+}
+
+// CloseExam POST /exams/:id/close
+// ends an active exam. It returns an error wrapping ErrInvalidTransition,
+// without calling the action, when the exam is not active.
+func (api *API) CloseExam(id int64) (Exam, error) {
+	return api.transitionExam(id, ExamStatusClosed, "close")
+}
+
+// transitionExam checks the current status of the exam allows moving to the
+// status to before posting the action. A 409 or 422 answer, the server
+// rejecting the transition, is reported as ErrInvalidTransition too.
+func (api *API) transitionExam(id int64, to ExamStatus, action string) (Exam, error) {
+	exam, err := api.Exam(id)
+	if err != nil {
+		return Exam{}, examError(err)
+	}
+	if !exam.Status.CanTransition(to) {
+		return Exam{}, fmt.Errorf("%w: %s to %s", ErrInvalidTransition, exam.Status, to)
+	}
+	exam, err = api.examAction(id, action)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusConflict || apiErr.StatusCode == http.StatusUnprocessableEntity) {
+		return Exam{}, fmt.Errorf("%w: %w", ErrInvalidTransition, err)
+	}
+
+	return exam, err
+}
+
+// ExamFilter server-side filters of ExamsWithFilter. Zero fields are not
+// applied.
+type ExamFilter struct {
+	// Name substring of the exam name
+	Name        string
+	InstituteID int64
+	// StartsAfter and EndsBefore bound the exam window
+	StartsAfter time.Time
+	EndsBefore  time.Time
+	// Status e.g. ExamStatusActive or ExamStatusClosed. Archived exams are
+	// listed by ArchivedExams.
+	Status ExamStatus
+}
+
+// queryParams returns the query params of the filter
+func (f ExamFilter) queryParams() map[string]string {
+	params := map[string]string{}
+	if f.Name != "" {
+		params["name"] = f.Name
+	}
+	if f.InstituteID != 0 {
+		params["institute_id"] = strconv.Itoa(int(f.InstituteID))
+	}
+	if !f.StartsAfter.IsZero() {
+		params["starts_after"] = f.StartsAfter.Format(time.RFC3339)
+	}
+	if !f.EndsBefore.IsZero() {
+		params["ends_before"] = f.EndsBefore.Format(time.RFC3339)
+	}
+	if f.Status != "" {
+		params["status"] = string(f.Status)
+	}
+	return params
+}
+
+// ExamsWithFilter GET /exams?name=&institute_id=&starts_after=&ends_before=&status=
+// returns the exams matching the filter
+func (api *API) ExamsWithFilter(filter ExamFilter) ([]Exam, error) {
+	path := fmt.Sprintf("%s/exams", apiPrefix)
+	params := getBaseParams()
+	queryParams := filter.queryParams()
+	for key, value := range queryParams {
+		params[key] = value
+	}
+	req, err := api.newGetRequest(path, params, queryParams)
+	if err != nil {
+		return nil, err
+	}
+	type examsWrapper struct {
+		Items []Exam `json:"exams"`
+	}
+	var exams examsWrapper
+	err = api.do(req, &exams)
+
+	return exams.Items, err
+}
@@ -0,0 +1,531 @@
+package proctorexam
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExamSettings(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	path := fmt.Sprintf("/api/v3/exams/%d/show_settings", idExam)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, fixture("exam_settings.json"))
+	})
+
+	settings, err := api.ExamSettings(idExam)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, int(settings.ExamID), idExam)
+	assert.Equal(t, "live", settings.ProctoringType)
+	assert.Equal(t, []string{"calculator", "scratch_paper"}, settings.AllowedResources)
+	assert.Equal(t, IdentityCheck{IDCard: true, FacePhoto: true}, settings.IdentityCheck)
+}
+
+func TestExamSettingsByIDs(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	for _, id := range []int64{17, 18, 19} {
+		id := id
+		mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/show_settings", id), func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{"settings": {"exam_id": %d, "proctoring_type": "live"}}`, id)
+		})
+	}
+	mux.HandleFunc("/api/v3/exams/20/show_settings", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"settings": `)
+	})
+
+	settings, err := api.ExamSettingsByIDs([]int64{17, 18, 19, 20})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "exam 20")
+	assert.Len(t, settings, 3)
+	for _, id := range []int64{17, 18, 19} {
+		assert.Equal(t, id, settings[id].ExamID)
+	}
+}
+
+func TestExamProctorRatio(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/show_settings", idExam), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, fixture("exam_settings.json"))
+	})
+	mux.HandleFunc("/api/v3/exams/18/show_settings", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"settings": {"exam_id": 18, "proctoring_type": "record_review"}}`)
+	})
+
+	ratio, err := api.ExamProctorRatio(idExam)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 6, ratio)
+
+	_, err = api.ExamProctorRatio(18)
+	assert.Equal(t, ErrProctorRatioUnset, err)
+}
+
+func TestExamResultsReleased(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/show_settings", idExam), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, fixture("exam_settings.json"))
+	})
+	mux.HandleFunc("/api/v3/exams/18/show_settings", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"settings": {"exam_id": 18, "results_released": false, "results_released_at": null}}`)
+	})
+
+	released, at, err := api.ExamResultsReleased(idExam)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, released)
+	if assert.NotNil(t, at) {
+		assert.Equal(t, time.Date(2020, 3, 9, 12, 30, 0, 0, time.UTC), *at)
+	}
+
+	released, at, err = api.ExamResultsReleased(18)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, released)
+	assert.Nil(t, at)
+}
+
+func TestReleaseExamResults(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	released := false
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d", idExam), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"exam": {"id": %d, "institute_id": %d, "name": "Physics", "results_released": %t}}`,
+			idExam, idInst, released)
+	})
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/release_results", idExam), func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		released = true
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"exam": {"id": %d, "institute_id": %d, "name": "Physics", "results_released": %t}}`,
+			idExam, idInst, released)
+	})
+
+	exam, err := api.Exam(idExam)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, exam.ResultsReleased)
+
+	exam, err = api.ReleaseExamResults(idExam)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int(exam.ID), idExam)
+	assert.True(t, exam.ResultsReleased)
+}
+
+func TestExamReviewerRules(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/show_settings", idExam), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, fixture("exam_settings.json"))
+	})
+	mux.HandleFunc("/api/v3/exams/18/show_settings", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"settings": {"exam_id": 18}}`)
+	})
+
+	rules, err := api.ExamReviewerRules(idExam)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "subject", rules.Strategy)
+	assert.Equal(t, []int64{11, 12}, rules.ReviewerIDs)
+	assert.Equal(t, "physics", rules.Parameters["subject"])
+
+	_, err = api.ExamReviewerRules(18)
+	assert.Equal(t, ErrReviewerRulesUnset, err)
+}
+
+func TestUpdateExam(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d", idExam), func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PATCH", r.Method)
+		assertSigned(t, r, map[string]string{"id": fmt.Sprint(idExam)})
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"exam": {"name": "Physics retake"}}`, string(body))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"exam": {"id": %d, "institute_id": %d, "name": "Physics retake"}}`, idExam, idInst)
+	})
+
+	name := "Physics retake"
+	exam, err := api.UpdateExam(idExam, ExamUpdate{Name: &name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "Physics retake", exam.Name)
+}
+
+func TestUpdateExamWindowAndSettings(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d", idExam), func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PATCH", r.Method)
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"exam": {
+			"starts_at": "2026-12-01T09:00:00Z",
+			"ends_at": "2026-12-01T12:00:00Z",
+			"max_students_per_proctor": 8
+		}}`, string(body))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"exam": {"id": %d, "starts_at": "2026-12-01T09:00:00Z", "ends_at": "2026-12-01T12:00:00Z"}}`, idExam)
+	})
+
+	start := time.Date(2026, 12, 1, 9, 0, 0, 0, time.UTC)
+	end := start.Add(3 * time.Hour)
+	ratio := 8
+	exam, err := api.UpdateExam(idExam, ExamUpdate{StartsAt: &start, EndsAt: &end, ProctorRatio: &ratio})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, exam.StartsAt.Equal(start))
+	assert.True(t, exam.EndsAt.Equal(end))
+}
+
+func TestDeleteExam(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	deleted := false
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d", idExam), func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		assertSigned(t, r, map[string]string{"id": fmt.Sprint(idExam)})
+		deleted = true
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{}`)
+	})
+	mux.HandleFunc("/api/v3/exams/18", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	assert.NoError(t, api.DeleteExam(idExam))
+	assert.True(t, deleted)
+
+	err := api.DeleteExam(18)
+	assert.True(t, errors.Is(err, ErrExamNotFound))
+	var apiErr *APIError
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	}
+
+	mux.HandleFunc("/api/v3/exams/19", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	})
+	err = api.DeleteExam(19)
+	assert.False(t, errors.Is(err, ErrExamNotFound))
+	assert.ErrorAs(t, err, &apiErr)
+}
+
+func TestExamScheduling(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v3/exams", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, fixture("exams_scheduling.json"))
+	})
+
+	exams, err := api.Exams()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 3, len(exams))
+	assert.Equal(t, "Physics", exams[0].Name)
+	assert.Equal(t, time.Date(2020, 3, 2, 9, 0, 0, 0, time.UTC), exams[0].StartsAt)
+	assert.Equal(t, time.Date(2020, 3, 2, 12, 0, 0, 0, time.UTC), exams[0].EndsAt)
+	for _, exam := range exams[1:] {
+		assert.True(t, exam.StartsAt.IsZero())
+		assert.True(t, exam.EndsAt.IsZero())
+	}
+
+	var exam Exam
+	assert.Error(t, json.Unmarshal([]byte(`{"id": 1, "starts_at": "tomorrow"}`), &exam))
+}
+
+func TestExamsByInstitute(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	path := fmt.Sprintf("/api/v3/institutes/%d/exams", idInst)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r, map[string]string{"institute_id": fmt.Sprint(idInst)})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, fixture("institute_exams.json"))
+	})
+
+	exams, err := api.ExamsByInstitute(idInst)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 2, len(exams))
+	for _, exam := range exams {
+		assert.Equal(t, int64(idInst), exam.InstituteID)
+	}
+}
+
+func TestCreateExam(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v3/exams", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "17", r.URL.Query().Get("institute_id"))
+		assertSigned(t, r, nil)
+
+		var body struct {
+			Exam map[string]interface{} `json:"exam"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, "Chemistry final", body.Exam["name"])
+		assert.Equal(t, float64(17), body.Exam["institute_id"])
+		assert.Equal(t, "2026-12-01T09:00:00Z", body.Exam["starts_at"])
+		assert.NotContains(t, body.Exam, "ends_at")
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, fixture("create_exam.json"))
+	})
+
+	exam, err := api.CreateExam(ExamInput{
+		Name:        "Chemistry final",
+		InstituteID: idInst,
+		StartsAt:    time.Date(2026, 12, 1, 9, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotZero(t, exam.ID)
+	assert.Equal(t, "Chemistry final", exam.Name)
+}
+
+func TestDuplicateExam(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	var bodies []string
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/duplicate", idExam), func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assertSigned(t, r, map[string]string{"id": fmt.Sprint(idExam)})
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"exam": {"id": 43, "institute_id": %d, "name": "Maths final (week 2)"}}`, idInst)
+	})
+
+	exam, err := api.DuplicateExam(idExam)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(43), exam.ID)
+
+	start := time.Date(2026, 12, 8, 9, 0, 0, 0, time.UTC)
+	_, err = api.DuplicateExam(idExam,
+		DuplicateName("Maths final (week 2)"),
+		DuplicateWindow(start, start.Add(2*time.Hour)),
+		DuplicateStudents())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.JSONEq(t, `{"exam": {"include_students": false}}`, bodies[0])
+	assert.JSONEq(t, `{"exam": {
+		"name": "Maths final (week 2)",
+		"starts_at": "2026-12-08T09:00:00Z",
+		"ends_at": "2026-12-08T11:00:00Z",
+		"include_students": true
+	}}`, bodies[1])
+}
+
+func TestArchivedExams(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	archived := map[int64]bool{18: true}
+	mux.HandleFunc("/api/v3/exams", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.URL.Query().Get("archived"))
+		assertSigned(t, r, nil)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		var exams []Exam
+		for id := range archived {
+			exams = append(exams, Exam{ID: id})
+		}
+		json.NewEncoder(w).Encode(map[string][]Exam{"exams": exams})
+	})
+	for _, action := range []string{"archive", "unarchive"} {
+		action := action
+		mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/%s", idExam, action), func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "POST", r.Method)
+			assertSigned(t, r, map[string]string{"id": fmt.Sprint(idExam)})
+			archived[idExam] = action == "archive"
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{"exam": {"id": %d, "archived": %t}}`, idExam, archived[idExam])
+		})
+	}
+
+	exam, err := api.ArchiveExam(idExam)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, exam.Archived)
+
+	exam, err = api.UnarchiveExam(idExam)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, exam.Archived)
+
+	delete(archived, idExam)
+	exams, err := api.ArchivedExams()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(exams))
+	assert.Equal(t, int64(18), exams[0].ID)
+}
+
+func TestExamsWithFilter(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	var queries []url.Values
+	mux.HandleFunc("/api/v3/exams", func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r, nil)
+		queries = append(queries, r.URL.Query())
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"exams": [{"id": 17, "name": "Maths & Physics"}]}`)
+	})
+
+	exams, err := api.ExamsWithFilter(ExamFilter{
+		Name:        "Maths & Physics",
+		InstituteID: idInst,
+		StartsAfter: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC),
+		Status:      ExamStatusActive,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(exams))
+
+	_, err = api.ExamsWithFilter(ExamFilter{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Maths & Physics", queries[0].Get("name"))
+	assert.Equal(t, "17", queries[0].Get("institute_id"))
+	assert.Equal(t, "2026-06-01T00:00:00Z", queries[0].Get("starts_after"))
+	assert.Equal(t, "active", queries[0].Get("status"))
+	assert.NotContains(t, queries[0], "ends_before")
+	assert.Equal(t, 3, len(queries[1]))
+}
+
+func TestExamTransitions(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	status := ExamStatusDraft
+	posted := 0
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d", idExam), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"exam": {"id": %d, "status": %q}}`, idExam, status)
+	})
+	for action, to := range map[string]ExamStatus{"activate": ExamStatusActive, "close": ExamStatusClosed} {
+		action, to := action, to
+		mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/%s", idExam, action), func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "POST", r.Method)
+			assertSigned(t, r, map[string]string{"id": fmt.Sprint(idExam)})
+			posted++
+			status = to
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{"exam": {"id": %d, "status": %q}}`, idExam, status)
+		})
+	}
+
+	_, err := api.CloseExam(idExam)
+	assert.ErrorIs(t, err, ErrInvalidTransition)
+	assert.Equal(t, 0, posted)
+
+	exam, err := api.ActivateExam(idExam)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, ExamStatusActive, exam.Status)
+
+	_, err = api.ActivateExam(idExam)
+	assert.ErrorIs(t, err, ErrInvalidTransition)
+
+	exam, err = api.CloseExam(idExam)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, ExamStatusClosed, exam.Status)
+	assert.Equal(t, 2, posted)
+
+	_, err = api.ActivateExam(99)
+	assert.ErrorIs(t, err, ErrExamNotFound)
+}
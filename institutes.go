@@ -0,0 +1,172 @@
+package proctorexam
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Institute organization owning exams and users
+type Institute struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	LogoImg  string `json:"logo_image"`
+	TimeZone string `json:"time_zone"`
+	// Settings institute-level settings, values decoded as by encoding/json
+	// (string, float64, bool...)
+	Settings map[string]interface{} `json:"settings"`
+}
+
+// ShowInstitute GET /institutes/:institute_id
+func (api *API) ShowInstitute(instituteID int64) (Institute, error) {
+	path := fmt.Sprintf("%s/institutes/%d", apiPrefix, instituteID)
+	params := getBaseParams()
+	params["institute_id"] = strconv.Itoa(int(instituteID))
+	req, err := api.newGetRequest(path, params, nil)
+	if err != nil {
+		return Institute{}, err
+	}
+	type instituteWrapper struct {
+		Item Institute `json:"institute"`
+	}
+	var wrapper instituteWrapper
+	err = api.do(req, &wrapper)
+
+	return wrapper.Item, err
+}
+
+// Institutes GET /institutes
+// returns the institutes the account has access to
+func (api *API) Institutes() ([]Institute, error) {
+	path := fmt.Sprintf("%s/institutes", apiPrefix)
+	params := getBaseParams()
+	req, err := api.newGetRequest(path, params, nil)
+	if err != nil {
+		return nil, err
+	}
+	type institutesWrapper struct {
+		Items []Institute `json:"institutes"`
+	}
+	var institutes institutesWrapper
+	err = api.do(req, &institutes)
+
+	return institutes.Items, err
+}
+
+// InstituteUpdate changes applied by UpdateInstitute. Nil fields are left
+// untouched.
+type InstituteUpdate struct {
+	Name            *string `json:"name,omitempty"`
+	LogoImg         *string `json:"logo_image,omitempty"`
+	DefaultLanguage *string `json:"default_language,omitempty"`
+	// NotificationEmail address notified of reviewed sessions
+	NotificationEmail *string `json:"notification_email,omitempty"`
+	// Settings institute-level settings to set, merged with the existing ones
+	Settings map[string]interface{} `json:"settings,omitempty"`
+}
+
+// UpdateInstitute PATCH /institutes/:institute_id
+func (api *API) UpdateInstitute(instituteID int64, changes InstituteUpdate) (Institute, error) {
+	path := fmt.Sprintf("%s/institutes/%d", apiPrefix, instituteID)
+	params := getBaseParams()
+	params["institute_id"] = strconv.Itoa(int(instituteID))
+	body := struct {
+		Institute InstituteUpdate `json:"institute"`
+	}{changes}
+	req, err := api.newPatchRequest(path, body, params, nil)
+	if err != nil {
+		return Institute{}, err
+	}
+	type instituteWrapper struct {
+		Item Institute `json:"institute"`
+	}
+	var wrapper instituteWrapper
+	err = api.do(req, &wrapper)
+
+	return wrapper.Item, err
+}
+
+// UploadInstituteLogo POST /institutes/:institute_id/logo
+// uploads the logo image of the institute, e.g. with the "image/png" content
+// type. The image is streamed, not loaded in memory.
+func (api *API) UploadInstituteLogo(instituteID int64, r io.Reader, contentType string) error {
+	path := fmt.Sprintf("%s/institutes/%d/logo", apiPrefix, instituteID)
+	params := getBaseParams()
+	params["institute_id"] = strconv.Itoa(int(instituteID))
+	req, err := api.newMultipartRequest(path, params, "logo_image", "logo", contentType, r)
+	if err != nil {
+		return err
+	}
+	defer req.Body.Close()
+
+	return api.do(req, nil)
+}
+
+// Usage plan of an institute and the proctoring credits consumed in the
+// current billing period
+type Usage struct {
+	Plan              string    `json:"plan"`
+	SessionsPurchased int       `json:"sessions_purchased"`
+	SessionsUsed      int       `json:"sessions_used"`
+	PeriodStart       time.Time `json:"period_start"`
+	PeriodEnd         time.Time `json:"period_end"`
+}
+
+// SessionsRemaining number of sessions left in the period, negative when the
+// quota has been exceeded
+func (u Usage) SessionsRemaining() int {
+	return u.SessionsPurchased - u.SessionsUsed
+}
+
+// InstituteUsage GET /institutes/:institute_id/usage
+func (api *API) InstituteUsage(instituteID int64) (Usage, error) {
+	path := fmt.Sprintf("%s/institutes/%d/usage", apiPrefix, instituteID)
+	params := getBaseParams()
+	params["institute_id"] = strconv.Itoa(int(instituteID))
+	req, err := api.newGetRequest(path, params, nil)
+	if err != nil {
+		return Usage{}, err
+	}
+	type usageWrapper struct {
+		Item Usage `json:"usage"`
+	}
+	var wrapper usageWrapper
+	err = api.do(req, &wrapper)
+
+	return wrapper.Item, err
+}
+
+// AuditEvent administrative action recorded for an institute
+type AuditEvent struct {
+	ID     int64  `json:"id"`
+	UserID int64  `json:"user_id"`
+	Action string `json:"action"`
+	// Target resource the action applied to, e.g. "exam:17"
+	Target string `json:"target"`
+	IP     string `json:"ip"`
+	// Details action specific values, decoded as by encoding/json
+	Details   map[string]interface{} `json:"details"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+// AuditLogs GET /institutes/:institute_id/audit_logs?since=
+// returns the administrative events recorded after since
+func (api *API) AuditLogs(instituteID int64, since time.Time) ([]AuditEvent, error) {
+	path := fmt.Sprintf("%s/institutes/%d/audit_logs", apiPrefix, instituteID)
+	params := getBaseParams()
+	from := since.UTC().Format(time.RFC3339)
+	params["institute_id"] = strconv.Itoa(int(instituteID))
+	params["since"] = from
+	req, err := api.newGetRequest(path, params, map[string]string{"since": from})
+	if err != nil {
+		return nil, err
+	}
+	type eventsWrapper struct {
+		Items []AuditEvent `json:"audit_logs"`
+	}
+	var events eventsWrapper
+	err = api.do(req, &events)
+
+	return events.Items, err
+}
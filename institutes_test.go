@@ -0,0 +1,156 @@
+package proctorexam
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShowInstitute(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/institutes/%d", idInst), func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r, map[string]string{"institute_id": fmt.Sprint(idInst)})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, fixture("institute.json"))
+	})
+
+	institute, err := api.ShowInstitute(idInst)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, int64(idInst), institute.ID)
+	assert.Equal(t, "Example University", institute.Name)
+	assert.Equal(t, "live", institute.Settings["default_proctoring_type"])
+	assert.Equal(t, float64(90), institute.Settings["retention_days"])
+	assert.Equal(t, true, institute.Settings["require_id_check"])
+}
+
+func TestInstitutes(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v3/institutes", func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r, nil)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, fixture("institutes.json"))
+	})
+
+	institutes, err := api.Institutes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 2, len(institutes))
+	assert.Equal(t, int64(18), institutes[1].ID)
+	assert.Equal(t, "Example College", institutes[1].Name)
+}
+
+func TestUpdateInstitute(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/institutes/%d", idInst), func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PATCH", r.Method)
+		assertSigned(t, r, map[string]string{"institute_id": fmt.Sprint(idInst)})
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"institute": {
+			"default_language": "nl",
+			"settings": {"retention_days": 30}
+		}}`, string(body))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"institute": {"id": %d, "settings": {"retention_days": 30}}}`, idInst)
+	})
+
+	language := "nl"
+	institute, err := api.UpdateInstitute(idInst, InstituteUpdate{
+		DefaultLanguage: &language,
+		Settings:        map[string]interface{}{"retention_days": 30},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, float64(30), institute.Settings["retention_days"])
+}
+
+func TestUploadInstituteLogo(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	png := "\x89PNG\r\n\x1a\n"
+	mux.HandleFunc(fmt.Sprintf("/api/v3/institutes/%d/logo", idInst), func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assertSigned(t, r, map[string]string{"institute_id": fmt.Sprint(idInst)})
+		file, header, err := r.FormFile("logo_image")
+		if assert.NoError(t, err) {
+			defer file.Close()
+			content, _ := io.ReadAll(file)
+			assert.Equal(t, "image/png", header.Header.Get("Content-Type"))
+			assert.Equal(t, png, string(content))
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	assert.NoError(t, api.UploadInstituteLogo(idInst, strings.NewReader(png), "image/png"))
+}
+
+func TestInstituteUsage(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/institutes/%d/usage", idInst), func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r, map[string]string{"institute_id": fmt.Sprint(idInst)})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"usage": {
+			"plan": "enterprise",
+			"sessions_purchased": 5000,
+			"sessions_used": 4820,
+			"period_start": "2026-01-01T00:00:00Z",
+			"period_end": "2026-12-31T23:59:59Z"
+		}}`)
+	})
+
+	usage, err := api.InstituteUsage(idInst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "enterprise", usage.Plan)
+	assert.Equal(t, 180, usage.SessionsRemaining())
+	assert.Equal(t, 2026, usage.PeriodEnd.Year())
+}
+
+func TestAuditLogs(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/institutes/%d/audit_logs", idInst), func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "2026-06-01T08:00:00Z", r.URL.Query().Get("since"))
+		assertSigned(t, r, map[string]string{"institute_id": fmt.Sprint(idInst)})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, fixture("audit_logs.json"))
+	})
+
+	since := time.Date(2026, 6, 1, 10, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	events, err := api.AuditLogs(idInst, since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, "exam.deleted", events[0].Action)
+	assert.Equal(t, "exam:18", events[0].Target)
+	assert.Equal(t, float64(42), events[0].Details["sessions"])
+	assert.Equal(t, false, events[0].Details["notified"])
+}
@@ -0,0 +1,90 @@
+package proctorexam
+
+import (
+	"errors"
+	"sync"
+)
+
+// maxNonceAttempts number of nonces drawn before giving up on finding one the
+// NonceStore has not seen
+const maxNonceAttempts = 10
+
+// defaultNonceCapacity number of nonces remembered by the default store
+const defaultNonceCapacity = 10000
+
+// ErrNonceExhausted is returned when no unused nonce could be generated
+var ErrNonceExhausted = errors.New("proctorexam: could not generate an unused nonce")
+
+// NonceStore records the nonces already sent so they are never reused, e.g.
+// across restarts when backed by persistent storage. Implementations must be
+// safe for concurrent use.
+type NonceStore interface {
+	Seen(nonce string) bool
+	Remember(nonce string)
+}
+
+// MemoryNonceStore in-memory NonceStore remembering the most recent nonces
+type MemoryNonceStore struct {
+	mu       sync.Mutex
+	capacity int
+	seen     map[string]struct{}
+	order    []string
+}
+
+// NewMemoryNonceStore creates a store remembering up to capacity nonces
+func NewMemoryNonceStore(capacity int) *MemoryNonceStore {
+	return &MemoryNonceStore{
+		capacity: capacity,
+		seen:     make(map[string]struct{}, capacity),
+	}
+}
+
+// Seen reports whether the nonce has been remembered
+func (s *MemoryNonceStore) Seen(nonce string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.seen[nonce]
+	return ok
+}
+
+// Remember records the nonce, forgetting the oldest one when full
+func (s *MemoryNonceStore) Remember(nonce string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.seen[nonce]; ok {
+		return
+	}
+	if s.capacity > 0 && len(s.order) >= s.capacity {
+		delete(s.seen, s.order[0])
+		s.order = s.order[1:]
+	}
+	s.seen[nonce] = struct{}{}
+	s.order = append(s.order, nonce)
+}
+
+// Nonces sets the store consulted before signing a request
+func Nonces(store NonceStore) Option {
+	return func(api *API) error {
+		if store == nil {
+			return errors.New("proctorexam: nil nonce store")
+		}
+		api.nonces = store
+		return nil
+	}
+}
+
+// claimNonce makes sure params carries a nonce the store has not seen,
+// drawing new ones on collision, and remembers it
+func (api *API) claimNonce(params map[string]string) error {
+	if api.nonces == nil {
+		return nil
+	}
+	for i := 0; i < maxNonceAttempts; i++ {
+		if !api.nonces.Seen(params["nonce"]) {
+			api.nonces.Remember(params["nonce"])
+			return nil
+		}
+		params["nonce"] = newNonce()
+	}
+	return ErrNonceExhausted
+}
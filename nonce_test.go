@@ -0,0 +1,79 @@
+package proctorexam
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// collidingStore reports the first nonces it is asked about as already seen
+type collidingStore struct {
+	collisions int
+	rejected   []string
+	remembered []string
+}
+
+func (s *collidingStore) Seen(nonce string) bool {
+	if len(s.rejected) < s.collisions {
+		s.rejected = append(s.rejected, nonce)
+		return true
+	}
+	return false
+}
+
+func (s *collidingStore) Remember(nonce string) {
+	s.remembered = append(s.remembered, nonce)
+}
+
+func TestNonceStoreCollision(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	var nonces []string
+	mux.HandleFunc("/api/v3/exams", func(w http.ResponseWriter, r *http.Request) {
+		nonces = append(nonces, r.URL.Query().Get("nonce"))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"exams": []}`)
+	})
+
+	store := &collidingStore{collisions: 2}
+	url, _ := url.Parse(server.URL)
+	client, err := New(BaseURL(url), Nonces(store))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.Exams()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 2, len(store.rejected))
+	assert.Equal(t, 1, len(nonces))
+	assert.NotContains(t, store.rejected, nonces[0])
+	assert.Equal(t, nonces, store.remembered)
+}
+
+func TestNonceStoreExhausted(t *testing.T) {
+	url, _ := url.Parse("http://localhost")
+	client, _ := New(BaseURL(url), Nonces(&collidingStore{collisions: maxNonceAttempts}))
+
+	_, err := client.Exams()
+	assert.Equal(t, ErrNonceExhausted, err)
+}
+
+func TestMemoryNonceStore(t *testing.T) {
+	store := NewMemoryNonceStore(2)
+	store.Remember("a")
+	store.Remember("b")
+	assert.True(t, store.Seen("a"))
+	assert.True(t, store.Seen("b"))
+
+	store.Remember("c")
+	assert.False(t, store.Seen("a"))
+	assert.True(t, store.Seen("c"))
+}
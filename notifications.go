@@ -0,0 +1,119 @@
+package proctorexam
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// NotificationSettings emails sent to the students of an exam
+type NotificationSettings struct {
+	ReminderEmails bool `json:"reminder_emails"`
+	// ReminderHoursBefore how long before the exam window the reminder is sent
+	ReminderHoursBefore int  `json:"reminder_hours_before"`
+	ResultsEmails       bool `json:"results_emails"`
+	InvitationEmails    bool `json:"invitation_emails"`
+}
+
+// NotificationUpdate changes applied by UpdateExamNotifications. Nil fields
+// are left untouched.
+type NotificationUpdate struct {
+	ReminderEmails      *bool `json:"reminder_emails,omitempty"`
+	ReminderHoursBefore *int  `json:"reminder_hours_before,omitempty"`
+	ResultsEmails       *bool `json:"results_emails,omitempty"`
+	InvitationEmails    *bool `json:"invitation_emails,omitempty"`
+}
+
+// ExamNotifications GET /exams/:id/notification_settings
+func (api *API) ExamNotifications(examID int64) (NotificationSettings, error) {
+	path := fmt.Sprintf("%s/exams/%d/notification_settings", apiPrefix, examID)
+	params := getBaseParams()
+	params["id"] = strconv.Itoa(int(examID))
+	req, err := api.newGetRequest(path, params, nil)
+	if err != nil {
+		return NotificationSettings{}, err
+	}
+	type settingsWrapper struct {
+		Item NotificationSettings `json:"notification_settings"`
+	}
+	var wrapper settingsWrapper
+	if err = api.do(req, &wrapper); err != nil {
+		return NotificationSettings{}, examError(err)
+	}
+
+	return wrapper.Item, nil
+}
+
+// UpdateExamNotifications PATCH /exams/:id/notification_settings
+func (api *API) UpdateExamNotifications(examID int64, changes NotificationUpdate) (NotificationSettings, error) {
+	path := fmt.Sprintf("%s/exams/%d/notification_settings", apiPrefix, examID)
+	params := getBaseParams()
+	params["id"] = strconv.Itoa(int(examID))
+	body := struct {
+		Settings NotificationUpdate `json:"notification_settings"`
+	}{changes}
+	req, err := api.newPatchRequest(path, body, params, nil)
+	if err != nil {
+		return NotificationSettings{}, err
+	}
+	type settingsWrapper struct {
+		Item NotificationSettings `json:"notification_settings"`
+	}
+	var wrapper settingsWrapper
+	if err = api.do(req, &wrapper); err != nil {
+		return NotificationSettings{}, examError(err)
+	}
+
+	return wrapper.Item, nil
+}
+
+// InvitationTemplate content of the invitation email of an exam. Body may use
+// the placeholders documented by ProctorExam, e.g. {{student_name}}.
+type InvitationTemplate struct {
+	Subject  string `json:"subject"`
+	Body     string `json:"body"`
+	Language string `json:"language"`
+}
+
+// ExamInvitationTemplate GET /exams/:id/invitation_template
+func (api *API) ExamInvitationTemplate(examID int64) (InvitationTemplate, error) {
+	path := fmt.Sprintf("%s/exams/%d/invitation_template", apiPrefix, examID)
+	params := getBaseParams()
+	params["id"] = strconv.Itoa(int(examID))
+	req, err := api.newGetRequest(path, params, nil)
+	if err != nil {
+		return InvitationTemplate{}, err
+	}
+	type templateWrapper struct {
+		Item InvitationTemplate `json:"invitation_template"`
+	}
+	var wrapper templateWrapper
+	if err = api.do(req, &wrapper); err != nil {
+		return InvitationTemplate{}, examError(err)
+	}
+
+	return wrapper.Item, nil
+}
+
+// UpdateExamInvitationTemplate PATCH /exams/:id/invitation_template
+// replaces the invitation email of the exam and returns the stored one
+func (api *API) UpdateExamInvitationTemplate(examID int64, template InvitationTemplate) (InvitationTemplate, error) {
+	path := fmt.Sprintf("%s/exams/%d/invitation_template", apiPrefix, examID)
+	params := getBaseParams()
+	params["id"] = strconv.Itoa(int(examID))
+	body := struct {
+		Template InvitationTemplate `json:"invitation_template"`
+	}{template}
+	req, err := api.newPatchRequest(path, body, params, nil)
+	if err != nil {
+		return InvitationTemplate{}, err
+	}
+	type templateWrapper struct {
+		Item InvitationTemplate `json:"invitation_template"`
+	}
+	var wrapper templateWrapper
+	if err = api.do(req, &wrapper); err != nil {
+		return InvitationTemplate{}, examError(err)
+	}
+
+	return wrapper.Item, nil
+}
@@ -0,0 +1,76 @@
+package proctorexam
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExamNotifications(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/notification_settings", idExam), func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r, map[string]string{"id": fmt.Sprint(idExam)})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if r.Method == "PATCH" {
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"notification_settings": {"reminder_hours_before": 48}}`, string(body))
+			fmt.Fprint(w, `{"notification_settings": {"reminder_emails": true, "reminder_hours_before": 48}}`)
+			return
+		}
+		fmt.Fprint(w, `{"notification_settings": {"reminder_emails": true, "reminder_hours_before": 24, "results_emails": true}}`)
+	})
+
+	settings, err := api.ExamNotifications(idExam)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, NotificationSettings{ReminderEmails: true, ReminderHoursBefore: 24, ResultsEmails: true}, settings)
+
+	hours := 48
+	settings, err = api.UpdateExamNotifications(idExam, NotificationUpdate{ReminderHoursBefore: &hours})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 48, settings.ReminderHoursBefore)
+}
+
+func TestExamInvitationTemplate(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	stored := InvitationTemplate{Subject: "Your exam", Body: "Hi {{student_name}}", Language: "en"}
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/invitation_template", idExam), func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r, map[string]string{"id": fmt.Sprint(idExam)})
+		if r.Method == "PATCH" {
+			var body struct {
+				Template InvitationTemplate `json:"invitation_template"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			stored = body.Template
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]InvitationTemplate{"invitation_template": stored})
+	})
+
+	template, err := api.ExamInvitationTemplate(idExam)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "Your exam", template.Subject)
+
+	template.Subject = "Your proctored exam"
+	template, err = api.UpdateExamInvitationTemplate(idExam, template)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "Your proctored exam", template.Subject)
+	assert.Equal(t, "Hi {{student_name}}", template.Body)
+}
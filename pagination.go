@@ -0,0 +1,264 @@
+package proctorexam
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// defaultPerPage page size used when walking every page of a list
+const defaultPerPage = 50
+
+// maxPages safety cap on the number of pages requested by an Iterator
+const maxPages = 1000
+
+// ErrTooManyPages is returned by an Iterator, and the methods reading every
+// page of a list, when a list has more than maxPages pages. The items read
+// until then are returned along with it.
+var ErrTooManyPages = errors.New("proctorexam: too many pages")
+
+// Links RFC 5988 pagination links of a list response
+type Links struct {
+	First string
+	Prev  string
+	Next  string
+	Last  string
+}
+
+// Response wraps the http response of an API call. Its body has already been
+// consumed.
+type Response struct {
+	*http.Response
+	Links Links
+	// Total number of items of a paginated list (X-Total header), 0 if unknown
+	Total int
+}
+
+// parseLinks parses a Link header such as
+// <https://host/api/v3/exams?page=2>; rel="next", <https://host/api/v3/exams?page=5>; rel="last"
+func parseLinks(header string) Links {
+	var links Links
+	for _, link := range strings.Split(header, ",") {
+		segments := strings.Split(link, ";")
+		target := strings.TrimSpace(segments[0])
+		if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+			continue
+		}
+		target = strings.Trim(target, "<>")
+		for _, segment := range segments[1:] {
+			param := strings.SplitN(strings.TrimSpace(segment), "=", 2)
+			if len(param) != 2 || param[0] != "rel" {
+				continue
+			}
+			for _, rel := range strings.Fields(strings.Trim(param[1], `"`)) {
+				switch rel {
+				case "first":
+					links.First = target
+				case "prev":
+					links.Prev = target
+				case "next":
+					links.Next = target
+				case "last":
+					links.Last = target
+				}
+			}
+		}
+	}
+
+	return links
+}
+
+// newLinkRequest builds a GET request following a pagination link. The query
+// of the link is added to the endpoint params and the request is signed again
+// with a fresh nonce and timestamp.
+func (api *API) newLinkRequest(link string, params map[string]string) (*http.Request, error) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return nil, err
+	}
+	signed := getBaseParams()
+	for key, value := range params {
+		if key != "nonce" && key != "timestamp" {
+			signed[key] = value
+		}
+	}
+	queryParams := map[string]string{}
+	for key, values := range u.Query() {
+		if key == "nonce" || key == "timestamp" || key == "signature" || len(values) == 0 {
+			continue
+		}
+		signed[key] = values[0]
+		queryParams[key] = values[0]
+	}
+
+	return api.newGetRequest(u.Path, signed, queryParams)
+}
+
+// pageParams returns copies of the signed params and the query params with the
+// page and per_page params added
+func pageParams(params map[string]string, page, perPage int) (map[string]string, map[string]string) {
+	signed := make(map[string]string, len(params)+2)
+	for key, value := range params {
+		signed[key] = value
+	}
+	queryParams := map[string]string{
+		"page":     strconv.Itoa(page),
+		"per_page": strconv.Itoa(perPage),
+	}
+	for key, value := range queryParams {
+		signed[key] = value
+	}
+	return signed, queryParams
+}
+
+// getPage fetches one page of a list endpoint whose items are under key
+func getPage[T any](api *API, req *http.Request, key string) ([]T, *Response, error) {
+	var wrapper map[string]json.RawMessage
+	resp, err := api.send(req, &wrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+	var items []T
+	if raw, ok := wrapper[key]; ok {
+		err = json.Unmarshal(raw, &items)
+	}
+	return items, resp, err
+}
+
+// Iterator walks the items of a list endpoint page by page, fetching a page
+// only once the items of the previous one have been consumed:
+//
+//	it := api.ExamsIter()
+//	for it.Next(ctx) {
+//		exam := it.Value()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+//
+// It follows the next link when the server sends one and requests the
+// following page number otherwise, signing every page with a fresh nonce.
+// Walking stops early when the server evidently ignores the paging params: a
+// page larger than requested, or a page identical to the previous one.
+type Iterator[T any] struct {
+	api    *API
+	path   string
+	key    string
+	params map[string]string
+
+	page     int
+	fetched  int
+	maxPages int
+	seen     int
+	next     string
+
+	items    []T
+	previous []T
+	index    int
+	value    T
+	err      error
+	done     bool
+}
+
+func newIterator[T any](api *API, path, key string, params map[string]string) *Iterator[T] {
+	return &Iterator[T]{api: api, path: path, key: key, params: params, page: 1, maxPages: maxPages}
+}
+
+// Next advances to the next item, fetching the following page with ctx when
+// needed. It returns false once every item has been read or on failure, see
+// Err.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	for it.index >= len(it.items) {
+		if it.done || it.err != nil {
+			return false
+		}
+		it.fetch(ctx)
+	}
+	it.value = it.items[it.index]
+	it.index++
+	return true
+}
+
+// Value returns the current item
+func (it *Iterator[T]) Value() T {
+	return it.value
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// fetch loads the next page
+func (it *Iterator[T]) fetch(ctx context.Context) {
+	api := it.api.WithContext(ctx)
+	var (
+		req *http.Request
+		err error
+	)
+	if it.next != "" {
+		req, err = api.newLinkRequest(it.next, it.params)
+	} else {
+		base := getBaseParams()
+		for key, value := range it.params {
+			base[key] = value
+		}
+		signed, queryParams := pageParams(base, it.page, defaultPerPage)
+		req, err = api.newGetRequest(it.path, signed, queryParams)
+	}
+	if err != nil {
+		it.err = err
+		return
+	}
+
+	items, resp, err := getPage[T](api, req, it.key)
+	it.fetched++
+	it.items, it.index = nil, 0
+	if err != nil {
+		it.err = err
+		return
+	}
+	if len(items) > 0 && reflect.DeepEqual(items, it.previous) {
+		it.done = true
+		return
+	}
+	it.items, it.previous = items, items
+	it.seen += len(items)
+
+	it.next = ""
+	switch {
+	case resp.Links.Next != "":
+		it.next = resp.Links.Next
+	case resp.Links != (Links{}) || len(items) == 0:
+		it.done = true
+	case len(items) > defaultPerPage:
+		it.done = true
+	case resp.Total > 0 && it.seen >= resp.Total:
+		it.done = true
+	case resp.Total == 0 && len(items) < defaultPerPage:
+		it.done = true
+	default:
+		it.page++
+	}
+	// the items of this page are still read before the error is reported
+	if !it.done && it.fetched >= it.maxPages {
+		it.err = ErrTooManyPages
+	}
+}
+
+// getAll reads every item of a list endpoint. The items fetched before a
+// failure are returned along with the error.
+func getAll[T any](api *API, path, key string, params map[string]string) ([]T, error) {
+	it := newIterator[T](api, path, key, params)
+	var all []T
+	for it.Next(api.context()) {
+		all = append(all, it.Value())
+	}
+	return all, it.Err()
+}
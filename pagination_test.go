@@ -0,0 +1,320 @@
+package proctorexam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLinks(t *testing.T) {
+	header := `<https://protos.proctorexam.com/api/v3/exams?page=1>; rel="first", ` +
+		`<https://protos.proctorexam.com/api/v3/exams?page=2>; rel="prev", ` +
+		`<https://protos.proctorexam.com/api/v3/exams?page=4>; rel="next", ` +
+		`<https://protos.proctorexam.com/api/v3/exams?page=9>; rel="last"`
+
+	links := parseLinks(header)
+	assert.Equal(t, "https://protos.proctorexam.com/api/v3/exams?page=1", links.First)
+	assert.Equal(t, "https://protos.proctorexam.com/api/v3/exams?page=2", links.Prev)
+	assert.Equal(t, "https://protos.proctorexam.com/api/v3/exams?page=4", links.Next)
+	assert.Equal(t, "https://protos.proctorexam.com/api/v3/exams?page=9", links.Last)
+
+	assert.Equal(t, Links{}, parseLinks(""))
+	assert.Equal(t, Links{}, parseLinks("garbage"))
+}
+
+func TestFollowNextLink(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	path := fmt.Sprintf("/api/v3/institutes/%d/users", idInst)
+
+	var cursors []string
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		cursor := query.Get("cursor")
+		cursors = append(cursors, cursor)
+
+		params := map[string]string{
+			"nonce":        query.Get("nonce"),
+			"timestamp":    query.Get("timestamp"),
+			"institute_id": fmt.Sprint(idInst),
+		}
+		for _, key := range []string{"page", "per_page", "cursor"} {
+			if query.Get(key) != "" {
+				params[key] = query.Get(key)
+			}
+		}
+		assert.Equal(t, api.signParams(params), query.Get("signature"))
+
+		w.Header().Set("Content-Type", "application/json")
+		if cursor == "" {
+			// the next link does not follow page numbers
+			w.Header().Set("Link", fmt.Sprintf(`<%s%s?cursor=u12>; rel="next"`, server.URL, path))
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `{"users": [{"id": 11}, {"id": 12}]}`)
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<%s%s>; rel="first"`, server.URL, path))
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"users": [{"id": 13}]}`)
+	})
+
+	users, err := api.UsersAll(idInst)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"", "u12"}, cursors)
+	assert.Equal(t, 3, len(users))
+	assert.Equal(t, int64(13), users[2].ID)
+}
+
+func TestExamsPaged(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v3/exams", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		assert.Equal(t, "3", query.Get("page"))
+		assert.Equal(t, "10", query.Get("per_page"))
+		params := map[string]string{
+			"nonce":     query.Get("nonce"),
+			"timestamp": query.Get("timestamp"),
+			"page":      "3",
+			"per_page":  "10",
+		}
+		assert.Equal(t, api.signParams(params), query.Get("signature"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Total", "42")
+		w.Header().Set("Link", fmt.Sprintf(`<%s/api/v3/exams?page=4&per_page=10>; rel="next"`, server.URL))
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"exams": [{"id": 21}, {"id": 22}]}`)
+	})
+
+	exams, resp, err := api.ExamsPaged(3, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, len(exams))
+	assert.Equal(t, 42, resp.Total)
+	assert.Equal(t, server.URL+"/api/v3/exams?page=4&per_page=10", resp.Links.Next)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestExamsAll(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	var pages []string
+	mux.HandleFunc("/api/v3/exams", func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Total", "3")
+		w.WriteHeader(http.StatusOK)
+		if page == "1" {
+			fmt.Fprint(w, `{"exams": [{"id": 1}, {"id": 2}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"exams": [{"id": 3}]}`)
+	})
+
+	exams, err := api.ExamsAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"1", "2"}, pages)
+	assert.Equal(t, 3, len(exams))
+}
+
+func TestUsersAll(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	path := fmt.Sprintf("/api/v3/institutes/%d/users", idInst)
+
+	var pages []string
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		page := query.Get("page")
+		pages = append(pages, page)
+		params := map[string]string{
+			"nonce":        query.Get("nonce"),
+			"timestamp":    query.Get("timestamp"),
+			"institute_id": fmt.Sprint(idInst),
+			"page":         page,
+			"per_page":     query.Get("per_page"),
+		}
+		assert.Equal(t, api.signParams(params), query.Get("signature"))
+
+		w.Header().Set("Content-Type", "application/json")
+		if page == "1" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s%s?page=2&per_page=50>; rel="next"`, server.URL, path))
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `{"users": [{"id": 11}, {"id": 12}]}`)
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<%s%s?page=1&per_page=50>; rel="first"`, server.URL, path))
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"users": [{"id": 13}]}`)
+	})
+
+	users, err := api.UsersAll(idInst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"1", "2"}, pages)
+	assert.Equal(t, 3, len(users))
+}
+
+func TestExamsAllServerIgnoresPaging(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	var items []string
+	for i := 1; i <= 60; i++ {
+		items = append(items, fmt.Sprintf(`{"id": %d}`, i))
+	}
+	calls, count := 0, 0
+	mux.HandleFunc("/api/v3/exams", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"exams": [%s]}`, strings.Join(items[:count], ", "))
+	})
+
+	// more items than requested
+	count = 60
+	exams, err := api.ExamsAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, 60, len(exams))
+
+	// a full page returned again and again
+	calls, count = 0, defaultPerPage
+	exams, err = api.ExamsAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, defaultPerPage, len(exams))
+}
+
+func TestIndexStudentsIter(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	path := fmt.Sprintf("/api/v3/exams/%d/index_students", idExam)
+
+	var pages []string
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+		assertSigned(t, r, map[string]string{"id": fmt.Sprint(idExam)})
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Total", "5")
+		switch page {
+		case "1":
+			w.Header().Set("Link", fmt.Sprintf(`<%s%s?page=2&per_page=50>; rel="next"`, server.URL, path))
+			fmt.Fprint(w, `{"students": [{"id": 1}, {"id": 2}]}`)
+		case "2":
+			w.Header().Set("Link", fmt.Sprintf(`<%s%s?page=3&per_page=50>; rel="next"`, server.URL, path))
+			fmt.Fprint(w, `{"students": [{"id": 3}, {"id": 4}]}`)
+		default:
+			w.Header().Set("Link", fmt.Sprintf(`<%s%s?page=1&per_page=50>; rel="first"`, server.URL, path))
+			fmt.Fprint(w, `{"students": [{"id": 5}]}`)
+		}
+	})
+
+	it := api.IndexStudentsIter(idExam)
+	var ids []int64
+	for it.Next(context.Background()) {
+		ids = append(ids, it.Value().ID)
+	}
+
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []int64{1, 2, 3, 4, 5}, ids)
+	assert.Equal(t, []string{"1", "2", "3"}, pages)
+	assert.False(t, it.Next(context.Background()))
+}
+
+func TestIterStopsOnError(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v3/exams", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Link", fmt.Sprintf(`<%s/api/v3/exams?page=2>; rel="next"`, server.URL))
+		fmt.Fprint(w, `{"exams": [{"id": 17}]}`)
+	})
+
+	it := api.ExamsIter()
+	count := 0
+	for it.Next(context.Background()) {
+		count++
+	}
+
+	assert.Equal(t, 1, count)
+	var apiErr *APIError
+	assert.True(t, errors.As(it.Err(), &apiErr))
+}
+
+func TestIndexStudentsPaged(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/index_students", idExam), func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		assert.Equal(t, "2", query.Get("page"))
+		assert.Equal(t, "100", query.Get("per_page"))
+		assertSigned(t, r, map[string]string{"id": fmt.Sprint(idExam)})
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Total", "1204")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"students": [{"id": 101}, {"id": 102}]}`)
+	})
+
+	students, resp, err := api.IndexStudentsPaged(idExam, 2, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, len(students))
+	assert.Equal(t, 1204, resp.Total)
+}
+
+func TestIterTooManyPages(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v3/exams", func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		w.Header().Set("Content-Type", "application/json")
+		// the server never stops sending a next link
+		w.Header().Set("Link", fmt.Sprintf(`<%s/api/v3/exams?page=%s0>; rel="next"`, server.URL, page))
+		fmt.Fprintf(w, `{"exams": [{"id": %d}]}`, len(page))
+	})
+
+	it := api.ExamsIter()
+	it.maxPages = 3
+	count := 0
+	for it.Next(context.Background()) {
+		count++
+	}
+
+	assert.Equal(t, 3, count)
+	assert.ErrorIs(t, it.Err(), ErrTooManyPages)
+}
@@ -0,0 +1,114 @@
+package proctorexam
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// DeletionJob erasure of the personal data and recordings of a student,
+// processed asynchronously by ProctorExam
+type DeletionJob struct {
+	ID    int64  `json:"id"`
+	Email string `json:"email"`
+	// Status "pending", "running", "completed" or "failed"
+	Status      string     `json:"status"`
+	RequestedAt time.Time  `json:"requested_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+}
+
+// Done reports whether the job is over, successfully or not
+func (j DeletionJob) Done() bool {
+	return j.Status == "completed" || j.Status == "failed"
+}
+
+// RequestDataDeletion POST /institutes/:institute_id/data_deletions
+// requests the deletion of the personal data and recordings of the student
+// with the given email across the institute. The returned job can be polled
+// with DataDeletion.
+func (api *API) RequestDataDeletion(instituteID int64, email string) (DeletionJob, error) {
+	path := fmt.Sprintf("%s/institutes/%d/data_deletions", apiPrefix, instituteID)
+	params := getBaseParams()
+	params["institute_id"] = strconv.Itoa(int(instituteID))
+	body := struct {
+		Email string `json:"email"`
+	}{email}
+	req, err := api.newPostRequest(path, body, params, nil)
+	if err != nil {
+		return DeletionJob{}, err
+	}
+	type jobWrapper struct {
+		Item DeletionJob `json:"data_deletion"`
+	}
+	var wrapper jobWrapper
+	err = api.do(req, &wrapper)
+
+	return wrapper.Item, err
+}
+
+// DataDeletion GET /institutes/:institute_id/data_deletions/:id
+func (api *API) DataDeletion(instituteID, jobID int64) (DeletionJob, error) {
+	path := fmt.Sprintf("%s/institutes/%d/data_deletions/%d", apiPrefix, instituteID, jobID)
+	params := getBaseParams()
+	params["id"] = strconv.Itoa(int(jobID))
+	params["institute_id"] = strconv.Itoa(int(instituteID))
+	req, err := api.newGetRequest(path, params, nil)
+	if err != nil {
+		return DeletionJob{}, err
+	}
+	type jobWrapper struct {
+		Item DeletionJob `json:"data_deletion"`
+	}
+	var wrapper jobWrapper
+	err = api.do(req, &wrapper)
+
+	return wrapper.Item, err
+}
+
+// StudentData everything held about a candidate for an exam, as gathered by
+// ExportStudentData
+type StudentData struct {
+	Student     Student          `json:"student"`
+	Events      []SessionEvent   `json:"events"`
+	Recordings  []Recording      `json:"recordings"`
+	Screenshots []Screenshot     `json:"screenshots"`
+	Comments    []SessionComment `json:"comments"`
+	// Review nil when the session has not been reviewed
+	Review *Review `json:"review"`
+}
+
+// ExportStudentData gathers the session metadata, events, recording and
+// screenshot links, comments and review of a student session, e.g. to answer
+// a subject access request. The bundle can be serialized with encoding/json.
+func (api *API) ExportStudentData(examID, studentSessionID int64) (StudentData, error) {
+	var (
+		data StudentData
+		err  error
+	)
+	if data.Student, err = api.ShowStudent(examID, studentSessionID); err != nil {
+		return StudentData{}, sessionError(err)
+	}
+	if data.Events, err = api.SessionEvents(examID, studentSessionID); err != nil {
+		return StudentData{}, err
+	}
+	if data.Recordings, err = api.SessionRecordings(examID, studentSessionID); err != nil {
+		return StudentData{}, err
+	}
+	if data.Screenshots, err = api.SessionScreenshots(examID, studentSessionID); err != nil {
+		return StudentData{}, err
+	}
+	if data.Comments, err = api.SessionComments(examID, studentSessionID); err != nil {
+		return StudentData{}, err
+	}
+	review, err := api.StudentReview(examID, studentSessionID)
+	switch {
+	case err == nil:
+		data.Review = &review
+	case !errors.Is(err, ErrSessionNotFound):
+		// the session exists, a 404 only means it has not been reviewed
+		return StudentData{}, err
+	}
+
+	return data, nil
+}
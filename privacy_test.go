@@ -0,0 +1,87 @@
+package proctorexam
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestDataDeletion(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	status := "pending"
+	path := fmt.Sprintf("/api/v3/institutes/%d/data_deletions", idInst)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assertSigned(t, r, map[string]string{"institute_id": fmt.Sprint(idInst)})
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"email": "jane.doe@example.com"}`, string(body))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprintf(w, `{"data_deletion": {"id": 7, "email": "jane.doe@example.com", "status": %q}}`, status)
+	})
+	mux.HandleFunc(path+"/7", func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r, map[string]string{"institute_id": fmt.Sprint(idInst), "id": "7"})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"data_deletion": {"id": 7, "status": "completed", "completed_at": "2026-06-20T02:00:00Z"}}`)
+	})
+
+	job, err := api.RequestDataDeletion(idInst, "jane.doe@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(7), job.ID)
+	assert.False(t, job.Done())
+
+	job, err = api.DataDeletion(idInst, job.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, job.Done())
+	assert.NotNil(t, job.CompletedAt)
+}
+
+func TestExportStudentData(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	respond := func(body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "4", r.URL.Query().Get("student_session_id"))
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, body)
+		}
+	}
+	prefix := fmt.Sprintf("/api/v3/exams/%d/", idExam)
+	mux.HandleFunc(prefix+"show_student", respond(fmt.Sprintf(`{"student": {"id": %d, "email": "jane.doe@example.com"}}`, idStudent)))
+	mux.HandleFunc(prefix+"student_events", respond(`{"events": [{"type": "started"}, {"type": "finished"}]}`))
+	mux.HandleFunc(prefix+"student_recordings", respond(`{"recordings": [{"kind": "webcam", "url": "https://recordings.example.com/4.webm"}]}`))
+	mux.HandleFunc(prefix+"student_screenshots", respond(`{"screenshots": []}`))
+	mux.HandleFunc(prefix+"student_comments", respond(`{"comments": [{"id": 1, "body": "ok"}]}`))
+	// not reviewed yet
+
+	data, err := api.ExportStudentData(idExam, idStudSession)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "jane.doe@example.com", data.Student.Email)
+	assert.Equal(t, 2, len(data.Events))
+	assert.Equal(t, "webcam", data.Recordings[0].Kind)
+	assert.Equal(t, 1, len(data.Comments))
+	assert.Nil(t, data.Review)
+
+	mux.HandleFunc(prefix+"student_review", respond(`{"review": {"verdict": "clean"}}`))
+	data, err = api.ExportStudentData(idExam, idStudSession)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.NotNil(t, data.Review) {
+		assert.Equal(t, "clean", data.Review.Verdict)
+	}
+}
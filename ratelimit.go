@@ -0,0 +1,58 @@
+package proctorexam
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimit quota reported by the X-RateLimit-* headers
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	// Reset when the quota is replenished
+	Reset time.Time
+}
+
+// rateLimitRecorder keeps the last reported quota, shared by the copies of a
+// client returned by WithContext
+type rateLimitRecorder struct {
+	mu   sync.Mutex
+	last *RateLimit
+}
+
+// record stores the quota reported by header, if any
+func (r *rateLimitRecorder) record(header http.Header) {
+	if r == nil {
+		return
+	}
+	limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return
+	}
+	rateLimit := RateLimit{Limit: limit}
+	rateLimit.Remaining, _ = strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		rateLimit.Reset = time.Unix(reset, 0)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.last = &rateLimit
+}
+
+// LastRateLimit returns the quota reported by the most recent response that
+// carried rate-limit headers. ok is false until such a response is received.
+func (api *API) LastRateLimit() (rateLimit RateLimit, ok bool) {
+	if api.rateLimit == nil {
+		return RateLimit{}, false
+	}
+	api.rateLimit.mu.Lock()
+	defer api.rateLimit.mu.Unlock()
+	if api.rateLimit.last == nil {
+		return RateLimit{}, false
+	}
+
+	return *api.rateLimit.last, true
+}
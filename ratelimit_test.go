@@ -0,0 +1,38 @@
+package proctorexam
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLastRateLimit(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v3/exams", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Limit", "600")
+		w.Header().Set("X-RateLimit-Remaining", "598")
+		w.Header().Set("X-RateLimit-Reset", "1583139600")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"exams": []}`)
+	})
+
+	_, ok := api.LastRateLimit()
+	assert.False(t, ok)
+
+	_, err := api.Exams()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rateLimit, ok := api.LastRateLimit()
+	assert.True(t, ok)
+	assert.Equal(t, 600, rateLimit.Limit)
+	assert.Equal(t, 598, rateLimit.Remaining)
+	assert.True(t, rateLimit.Reset.Equal(time.Unix(1583139600, 0)))
+}
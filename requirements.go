@@ -0,0 +1,67 @@
+package proctorexam
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// SystemRequirements setup the candidates need to take a proctored exam
+type SystemRequirements struct {
+	// Browsers minimum major version per supported browser, e.g. "chrome": 110
+	Browsers         map[string]int `json:"browsers"`
+	OperatingSystems []string       `json:"operating_systems"`
+	MinBandwidthKbps int            `json:"min_bandwidth_kbps"`
+	Webcam           bool           `json:"webcam"`
+	Microphone       bool           `json:"microphone"`
+	ScreenSharing    bool           `json:"screen_sharing"`
+}
+
+// SystemCheck result of the system check a candidate ran before the exam
+type SystemCheck struct {
+	Passed bool `json:"passed"`
+	// Checks outcome of each check, e.g. "webcam": true
+	Checks    map[string]bool `json:"checks"`
+	CheckedAt *time.Time      `json:"checked_at"`
+}
+
+// SystemRequirements GET /system_requirements
+func (api *API) SystemRequirements() (SystemRequirements, error) {
+	path := fmt.Sprintf("%s/system_requirements", apiPrefix)
+	params := getBaseParams()
+	req, err := api.newGetRequest(path, params, nil)
+	if err != nil {
+		return SystemRequirements{}, err
+	}
+	type requirementsWrapper struct {
+		Item SystemRequirements `json:"system_requirements"`
+	}
+	var wrapper requirementsWrapper
+	err = api.do(req, &wrapper)
+
+	return wrapper.Item, err
+}
+
+// SessionSystemCheck GET /exams/:id/system_check?student_session_id=
+// returns the last system check run by the student, CheckedAt is nil if the
+// student has not run it yet
+func (api *API) SessionSystemCheck(examID, studentSessionID int64) (SystemCheck, error) {
+	path := fmt.Sprintf("%s/exams/%d/system_check", apiPrefix, examID)
+	params := getBaseParams()
+	sessionID := strconv.Itoa(int(studentSessionID))
+	params["student_session_id"] = sessionID
+	params["id"] = strconv.Itoa(int(examID))
+	req, err := api.newGetRequest(path, params, map[string]string{"student_session_id": sessionID})
+	if err != nil {
+		return SystemCheck{}, err
+	}
+	type checkWrapper struct {
+		Item SystemCheck `json:"system_check"`
+	}
+	var wrapper checkWrapper
+	if err = api.do(req, &wrapper); err != nil {
+		return SystemCheck{}, sessionError(err)
+	}
+
+	return wrapper.Item, nil
+}
@@ -0,0 +1,61 @@
+package proctorexam
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSystemRequirements(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v3/system_requirements", func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r, nil)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"system_requirements": {
+			"browsers": {"chrome": 110, "firefox": 115},
+			"operating_systems": ["windows", "macos"],
+			"min_bandwidth_kbps": 1024,
+			"webcam": true,
+			"microphone": true,
+			"screen_sharing": true
+		}}`)
+	})
+
+	requirements, err := api.SystemRequirements()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 110, requirements.Browsers["chrome"])
+	assert.Equal(t, 1024, requirements.MinBandwidthKbps)
+	assert.True(t, requirements.ScreenSharing)
+}
+
+func TestSessionSystemCheck(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/system_check", idExam), func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "4", r.URL.Query().Get("student_session_id"))
+		assertSigned(t, r, map[string]string{"id": fmt.Sprint(idExam)})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"system_check": {
+			"passed": false,
+			"checks": {"webcam": true, "bandwidth": false},
+			"checked_at": "2026-06-11T18:30:00Z"
+		}}`)
+	})
+
+	check, err := api.SessionSystemCheck(idExam, idStudSession)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, check.Passed)
+	assert.False(t, check.Checks["bandwidth"])
+	assert.NotNil(t, check.CheckedAt)
+}
@@ -0,0 +1,114 @@
+package proctorexam
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// maxRetryDelay upper bound of the delay before a retry, whether requested by
+// a Retry-After header or computed by the exponential backoff
+const maxRetryDelay = time.Minute
+
+// signedParamsKey context key holding the params a request was signed with,
+// so it can be signed again with a fresh nonce when retried
+type signedParamsKey struct{}
+
+type signedParams struct {
+	params      map[string]string
+	queryParams map[string]string
+}
+
+// Retry retries GET requests answered with 429, 502, 503 or 504 up to
+// maxRetries times. It waits for the Retry-After delay when the server sends
+// one and backs off exponentially from baseDelay otherwise, with jitter and
+// up to a minute.
+func Retry(maxRetries int, baseDelay time.Duration) Option {
+	return func(api *API) error {
+		if maxRetries < 0 || baseDelay < 0 {
+			return errors.New("proctorexam: invalid retry settings")
+		}
+		api.maxRetries = maxRetries
+		api.retryDelay = baseDelay
+		return nil
+	}
+}
+
+// retryable reports whether a request answered with resp can be retried
+func retryable(req *http.Request, resp *Response) bool {
+	if req.Method != "GET" || resp == nil {
+		return false
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryDelayFor returns how long to wait before the given retry (starting at
+// 0). Both Retry-After delays and the backoff are capped to maxRetryDelay. The
+// backoff is jittered between half and all of its value, so that clients
+// failing together do not retry in lockstep.
+func (api *API) retryDelayFor(resp *Response, retry int) time.Duration {
+	if after := resp.Header.Get("Retry-After"); after != "" {
+		if seconds, err := strconv.Atoi(after); err == nil && seconds >= 0 {
+			return min(time.Duration(seconds)*time.Second, maxRetryDelay)
+		}
+		if date, err := http.ParseTime(after); err == nil {
+			return min(max(time.Until(date), 0), maxRetryDelay)
+		}
+	}
+	backoff := api.retryDelay
+	for i := 0; i < retry && backoff < maxRetryDelay; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, maxRetryDelay)
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
+
+// resign returns a copy of req signed again with a fresh nonce and timestamp
+func (api *API) resign(req *http.Request) (*http.Request, error) {
+	signed, ok := req.Context().Value(signedParamsKey{}).(signedParams)
+	if !ok {
+		return req, nil
+	}
+	params := getBaseParams()
+	for key, value := range signed.params {
+		if key != "nonce" && key != "timestamp" {
+			params[key] = value
+		}
+	}
+	if err := api.claimNonce(params); err != nil {
+		return nil, err
+	}
+	signature := api.signParams(params)
+
+	ctx := context.WithValue(req.Context(), signedParamsKey{}, signedParams{params, signed.queryParams})
+	retry := req.Clone(ctx)
+	retry.URL.RawQuery = encodeQuery(params["nonce"], params["timestamp"], signature, signed.queryParams)
+
+	return retry, nil
+}
+
+// wait sleeps for d unless ctx is cancelled or the client closed first
+func (api *API) wait(ctx context.Context, d time.Duration) error {
+	var done <-chan struct{}
+	if api.done != nil {
+		done = api.done.Done()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-done:
+		return ErrClientClosed
+	}
+}
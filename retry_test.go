@@ -0,0 +1,145 @@
+package proctorexam
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetry(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	var nonces []string
+	mux.HandleFunc("/api/v3/exams", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		nonces = append(nonces, query.Get("nonce"))
+		params := map[string]string{
+			"nonce":     query.Get("nonce"),
+			"timestamp": query.Get("timestamp"),
+		}
+		assert.Equal(t, api.signParams(params), query.Get("signature"))
+
+		switch len(nonces) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `{"exams": [{"id": 17}]}`)
+		}
+	})
+
+	url, _ := url.Parse(server.URL)
+	client, _ := New(BaseURL(url), Retry(3, time.Millisecond))
+
+	exams, err := client.Exams()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(exams))
+	assert.Equal(t, 3, len(nonces))
+	assert.NotEqual(t, nonces[0], nonces[1])
+	assert.NotEqual(t, nonces[1], nonces[2])
+
+	stats, _ := client.LastRequestStats()
+	assert.Equal(t, 2, stats.Retries)
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/api/v3/exams", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	url, _ := url.Parse(server.URL)
+	client, _ := New(BaseURL(url), Retry(2, time.Millisecond))
+
+	_, err := client.Exams()
+	var apiErr *APIError
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+	}
+	assert.Equal(t, 3, calls)
+}
+
+func TestRetryNonRetryableStatus(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/api/v3/exams", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	url, _ := url.Parse(server.URL)
+	client, _ := New(BaseURL(url), Retry(3, time.Hour))
+
+	start := time.Now()
+	_, err := client.Exams()
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestRetryInterruptedByClose(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v3/exams", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	url, _ := url.Parse(server.URL)
+	client, _ := New(BaseURL(url), Retry(3, time.Millisecond))
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		client.Close()
+	}()
+
+	start := time.Now()
+	_, err := client.Exams()
+	assert.Equal(t, ErrClientClosed, err)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestRetryAfterCapped(t *testing.T) {
+	resp := &Response{Response: &http.Response{Header: http.Header{"Retry-After": []string{"86400"}}}}
+	assert.Equal(t, maxRetryDelay, (&API{}).retryDelayFor(resp, 0))
+}
+
+func TestRetryBackoffCappedAndJittered(t *testing.T) {
+	api := &API{retryDelay: time.Second}
+	resp := &Response{Response: &http.Response{Header: http.Header{}}}
+
+	for retry := 0; retry < 4; retry++ {
+		backoff := time.Second << retry
+		delay := api.retryDelayFor(resp, retry)
+		assert.GreaterOrEqual(t, delay, backoff/2)
+		assert.LessOrEqual(t, delay, backoff)
+	}
+
+	delays := map[time.Duration]bool{}
+	for i := 0; i < 10; i++ {
+		delay := api.retryDelayFor(resp, 100)
+		assert.GreaterOrEqual(t, delay, maxRetryDelay/2)
+		assert.LessOrEqual(t, delay, maxRetryDelay)
+		delays[delay] = true
+	}
+	assert.Greater(t, len(delays), 1)
+}
@@ -0,0 +1,176 @@
+package proctorexam
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// FlagSeverity how suspicious a flagged incident is. Values unknown to this
+// version of the sdk are kept as sent by the server.
+type FlagSeverity string
+
+// Documented flag severities
+const (
+	FlagSeverityLow    FlagSeverity = "low"
+	FlagSeverityMedium FlagSeverity = "medium"
+	FlagSeverityHigh   FlagSeverity = "high"
+)
+
+// ReviewFlag incident raised by the reviewer of a session
+type ReviewFlag struct {
+	Type     string       `json:"type"`
+	Severity FlagSeverity `json:"severity"`
+	// Offset seconds from the start of the recording
+	Offset int64 `json:"offset"`
+	// CreatedAt when the incident happened, zero if not reported
+	CreatedAt time.Time `json:"created_at"`
+	Comment   string    `json:"comment"`
+}
+
+// Review outcome of the review of a student session
+type Review struct {
+	StudentSessionID int64  `json:"student_session_id"`
+	ReviewerID       int64  `json:"reviewer_id"`
+	Verdict          string `json:"verdict"`
+	// Comment overall remarks of the reviewer
+	Comment    string       `json:"comment"`
+	ReviewedAt *time.Time   `json:"reviewed_at"`
+	Flags      []ReviewFlag `json:"flags"`
+	// recordings of the session, empty when not recorded
+	VideoURL  string `json:"video_url"`
+	ScreenURL string `json:"screen_url"`
+	MobileURL string `json:"mobile_url"`
+}
+
+// StudentReview GET /exams/:id/student_review?student_session_id=
+func (api *API) StudentReview(examID, studentSessionID int64) (Review, error) {
+	path := fmt.Sprintf("%s/exams/%d/student_review", apiPrefix, examID)
+	params := getBaseParams()
+	sessionID := strconv.Itoa(int(studentSessionID))
+	params["student_session_id"] = sessionID
+	params["id"] = strconv.Itoa(int(examID))
+	req, err := api.newGetRequest(path, params, map[string]string{"student_session_id": sessionID})
+	if err != nil {
+		return Review{}, err
+	}
+	type reviewWrapper struct {
+		Item Review `json:"review"`
+	}
+	var wrapper reviewWrapper
+	if err = api.do(req, &wrapper); err != nil {
+		return Review{}, sessionError(err)
+	}
+
+	return wrapper.Item, nil
+}
+
+// ExamResult proctoring outcome of a student of the exam
+type ExamResult struct {
+	Student Student `json:"student"`
+	// Review zero when the session has not been reviewed yet
+	Review Review `json:"review"`
+}
+
+// ExamResults GET /exams/:id/results
+// returns the review outcome of every student of the exam
+func (api *API) ExamResults(examID int64) ([]ExamResult, error) {
+	path := fmt.Sprintf("%s/exams/%d/results", apiPrefix, examID)
+	params := getBaseParams()
+	params["id"] = strconv.Itoa(int(examID))
+	req, err := api.newGetRequest(path, params, nil)
+	if err != nil {
+		return nil, err
+	}
+	type resultsWrapper struct {
+		Items []ExamResult `json:"results"`
+	}
+	var results resultsWrapper
+	if err = api.do(req, &results); err != nil {
+		return nil, examError(err)
+	}
+
+	return results.Items, nil
+}
+
+// SessionFlags GET /exams/:id/student_flags?student_session_id=
+// returns the incidents flagged during the review of a session
+func (api *API) SessionFlags(examID, studentSessionID int64) ([]ReviewFlag, error) {
+	path := fmt.Sprintf("%s/exams/%d/student_flags", apiPrefix, examID)
+	params := getBaseParams()
+	sessionID := strconv.Itoa(int(studentSessionID))
+	params["student_session_id"] = sessionID
+	params["id"] = strconv.Itoa(int(examID))
+	req, err := api.newGetRequest(path, params, map[string]string{"student_session_id": sessionID})
+	if err != nil {
+		return nil, err
+	}
+	type flagsWrapper struct {
+		Items []ReviewFlag `json:"flags"`
+	}
+	var flags flagsWrapper
+	if err = api.do(req, &flags); err != nil {
+		return nil, sessionError(err)
+	}
+
+	return flags.Items, nil
+}
+
+// SessionComment note left by a reviewer on a student session
+type SessionComment struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"user_id"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SessionComments GET /exams/:id/student_comments?student_session_id=
+func (api *API) SessionComments(examID, studentSessionID int64) ([]SessionComment, error) {
+	path := fmt.Sprintf("%s/exams/%d/student_comments", apiPrefix, examID)
+	params := getBaseParams()
+	sessionID := strconv.Itoa(int(studentSessionID))
+	params["student_session_id"] = sessionID
+	params["id"] = strconv.Itoa(int(examID))
+	req, err := api.newGetRequest(path, params, map[string]string{"student_session_id": sessionID})
+	if err != nil {
+		return nil, err
+	}
+	type commentsWrapper struct {
+		Items []SessionComment `json:"comments"`
+	}
+	var comments commentsWrapper
+	if err = api.do(req, &comments); err != nil {
+		return nil, sessionError(err)
+	}
+
+	return comments.Items, nil
+}
+
+// AddSessionComment POST /exams/:id/student_comments?student_session_id=
+// returns the created comment
+func (api *API) AddSessionComment(examID, studentSessionID int64, body string) (SessionComment, error) {
+	path := fmt.Sprintf("%s/exams/%d/student_comments", apiPrefix, examID)
+	params := getBaseParams()
+	sessionID := strconv.Itoa(int(studentSessionID))
+	params["student_session_id"] = sessionID
+	params["id"] = strconv.Itoa(int(examID))
+	input := struct {
+		Comment struct {
+			Body string `json:"body"`
+		} `json:"comment"`
+	}{}
+	input.Comment.Body = body
+	req, err := api.newPostRequest(path, input, params, map[string]string{"student_session_id": sessionID})
+	if err != nil {
+		return SessionComment{}, err
+	}
+	type commentWrapper struct {
+		Item SessionComment `json:"comment"`
+	}
+	var wrapper commentWrapper
+	if err = api.do(req, &wrapper); err != nil {
+		return SessionComment{}, sessionError(err)
+	}
+
+	return wrapper.Item, nil
+}
@@ -0,0 +1,136 @@
+package proctorexam
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStudentReview(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	path := fmt.Sprintf("/api/v3/exams/%d/student_review", idExam)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "4", r.URL.Query().Get("student_session_id"))
+		assertSigned(t, r, map[string]string{"id": fmt.Sprint(idExam)})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, fixture("student_review.json"))
+	})
+
+	review, err := api.StudentReview(idExam, idStudSession)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "suspicious", review.Verdict)
+	assert.Equal(t, 2, len(review.Flags))
+	assert.Equal(t, "multiple_faces", review.Flags[0].Type)
+	assert.Equal(t, FlagSeverityHigh, review.Flags[0].Severity)
+	assert.Equal(t, int64(312), review.Flags[0].Offset)
+	assert.Equal(t, "https://recordings.proctorexam.com/17/4/webcam.webm", review.VideoURL)
+	assert.Equal(t, "https://recordings.proctorexam.com/17/4/screen.webm", review.ScreenURL)
+	assert.Equal(t, "", review.MobileURL)
+}
+
+func TestExamResults(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/results", idExam), func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r, map[string]string{"id": fmt.Sprint(idExam)})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, fixture("exam_results.json"))
+	})
+
+	results, err := api.ExamResults(idExam)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 2, len(results))
+	assert.True(t, results[0].Student.Status.IsFinished())
+	assert.Equal(t, "suspicious", results[0].Review.Verdict)
+	assert.Equal(t, "second person visible twice", results[0].Review.Comment)
+	assert.Equal(t, 1, len(results[0].Review.Flags))
+	assert.Equal(t, Review{}, results[1].Review)
+}
+
+func TestSessionFlags(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/student_flags", idExam), func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "4", r.URL.Query().Get("student_session_id"))
+		assertSigned(t, r, map[string]string{"id": fmt.Sprint(idExam)})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"flags": [
+			{"type": "tab_switch", "severity": "medium", "offset": 65, "created_at": "2026-06-12T09:01:10Z"},
+			{"type": "voice", "severity": "critical", "offset": 90, "created_at": "2026-06-12T09:01:35Z"}
+		]}`)
+	})
+
+	flags, err := api.SessionFlags(idExam, idStudSession)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 2, len(flags))
+	assert.Equal(t, FlagSeverityMedium, flags[0].Severity)
+	assert.Equal(t, time.Date(2026, 6, 12, 9, 1, 10, 0, time.UTC), flags[0].CreatedAt)
+	assert.Equal(t, FlagSeverity("critical"), flags[1].Severity)
+}
+
+func TestSessionComments(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	var comments []string
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/student_comments", idExam), func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "4", r.URL.Query().Get("student_session_id"))
+		assertSigned(t, r, map[string]string{"id": fmt.Sprint(idExam)})
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "POST" {
+			var body struct {
+				Comment struct {
+					Body string `json:"body"`
+				} `json:"comment"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			comments = append(comments, body.Comment.Body)
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"comment": {"id": %d, "body": %q}}`, len(comments), body.Comment.Body)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"comments": [`)
+		for i, comment := range comments {
+			if i > 0 {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprintf(w, `{"id": %d, "body": %q}`, i+1, comment)
+		}
+		fmt.Fprint(w, `]}`)
+	})
+
+	comment, err := api.AddSessionComment(idExam, idStudSession, "identity confirmed by phone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(1), comment.ID)
+
+	list, err := api.SessionComments(idExam, idStudSession)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(list))
+	assert.Equal(t, "identity confirmed by phone", list[0].Body)
+}
@@ -0,0 +1,82 @@
+package proctorexam
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// SEBSettings Safe Exam Browser configuration of an exam
+type SEBSettings struct {
+	// QuitURL page ending the SEB session once the exam is handed in
+	QuitURL         string `json:"quit_url"`
+	QuitPassword    string `json:"quit_password"`
+	AllowSpellCheck bool   `json:"allow_spell_check"`
+	AllowQuit       bool   `json:"allow_quit"`
+	// AllowedURLs url filter patterns the students can browse to
+	AllowedURLs []string `json:"allowed_urls"`
+}
+
+// ExamSEBSettings GET /exams/:id/seb_settings
+func (api *API) ExamSEBSettings(examID int64) (SEBSettings, error) {
+	path := fmt.Sprintf("%s/exams/%d/seb_settings", apiPrefix, examID)
+	params := getBaseParams()
+	params["id"] = strconv.Itoa(int(examID))
+	req, err := api.newGetRequest(path, params, nil)
+	if err != nil {
+		return SEBSettings{}, err
+	}
+	type settingsWrapper struct {
+		Item SEBSettings `json:"seb_settings"`
+	}
+	var wrapper settingsWrapper
+	if err = api.do(req, &wrapper); err != nil {
+		return SEBSettings{}, examError(err)
+	}
+
+	return wrapper.Item, nil
+}
+
+// UpdateExamSEBSettings PATCH /exams/:id/seb_settings
+// replaces the SEB configuration of the exam and returns the stored one
+func (api *API) UpdateExamSEBSettings(examID int64, settings SEBSettings) (SEBSettings, error) {
+	path := fmt.Sprintf("%s/exams/%d/seb_settings", apiPrefix, examID)
+	params := getBaseParams()
+	params["id"] = strconv.Itoa(int(examID))
+	body := struct {
+		Settings SEBSettings `json:"seb_settings"`
+	}{settings}
+	req, err := api.newPatchRequest(path, body, params, nil)
+	if err != nil {
+		return SEBSettings{}, err
+	}
+	type settingsWrapper struct {
+		Item SEBSettings `json:"seb_settings"`
+	}
+	var wrapper settingsWrapper
+	if err = api.do(req, &wrapper); err != nil {
+		return SEBSettings{}, examError(err)
+	}
+
+	return wrapper.Item, nil
+}
+
+// DownloadSEBConfig GET /exams/:id/seb_config
+// writes the generated .seb file of the exam into w, so it can be handed out
+// to the students
+func (api *API) DownloadSEBConfig(examID int64, w io.Writer) (int64, error) {
+	path := fmt.Sprintf("%s/exams/%d/seb_config", apiPrefix, examID)
+	params := getBaseParams()
+	params["id"] = strconv.Itoa(int(examID))
+	req, err := api.newGetRequest(path, params, nil)
+	if err != nil {
+		return 0, err
+	}
+	var config []byte
+	if err = api.do(req, &config); err != nil {
+		return 0, examError(err)
+	}
+	n, err := w.Write(config)
+
+	return int64(n), err
+}
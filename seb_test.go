@@ -0,0 +1,75 @@
+package proctorexam
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExamSEBSettings(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	stored := SEBSettings{QuitURL: "https://lms.example.com/done", AllowedURLs: []string{"*.example.com"}}
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/seb_settings", idExam), func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r, map[string]string{"id": fmt.Sprint(idExam)})
+		if r.Method == "PATCH" {
+			var body struct {
+				Settings SEBSettings `json:"seb_settings"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			stored = body.Settings
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]SEBSettings{"seb_settings": stored})
+	})
+
+	settings, err := api.ExamSEBSettings(idExam)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "https://lms.example.com/done", settings.QuitURL)
+
+	settings.AllowSpellCheck = true
+	updated, err := api.UpdateExamSEBSettings(idExam, settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, updated.AllowSpellCheck)
+	assert.Equal(t, []string{"*.example.com"}, updated.AllowedURLs)
+}
+
+func TestDownloadSEBConfig(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	config := []byte("<?xml version=\"1.0\"?><plist><dict/></plist>")
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/seb_config", idExam), func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r, map[string]string{"id": fmt.Sprint(idExam)})
+		assert.Equal(t, "application/vnd.procwise.v3", r.Header.Get("Accept"))
+		w.Header().Set("Content-Type", "application/seb")
+		w.WriteHeader(http.StatusOK)
+		w.Write(config)
+	})
+
+	var buf bytes.Buffer
+	n, err := api.DownloadSEBConfig(idExam, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(len(config)), n)
+	assert.Equal(t, config, buf.Bytes())
+	stats, err := api.LastRequestStats()
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(len(config)), stats.BytesRead)
+	}
+
+	_, err = api.DownloadSEBConfig(18, &buf)
+	assert.True(t, errors.Is(err, ErrExamNotFound))
+}
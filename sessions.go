@@ -0,0 +1,624 @@
+package proctorexam
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrSessionNotFound is returned when the requested student session does not
+// exist
+var ErrSessionNotFound = errors.New("proctorexam: student session not found")
+
+// ErrSessionStarted is returned when deleting a student session that has
+// already started
+var ErrSessionStarted = errors.New("proctorexam: student session already started")
+
+// ErrReopenNotAllowed is returned when a student session can not be reopened,
+// e.g. because it has been reviewed or the exam window is over
+var ErrReopenNotAllowed = errors.New("proctorexam: student session can not be reopened")
+
+// ErrNoLaunchURL is returned by StudentLaunchURL when the server issued no
+// link for the student session
+var ErrNoLaunchURL = errors.New("proctorexam: no launch url issued for the student session")
+
+// studentSortKeys fields IndexStudentsSorted can order the students by
+var studentSortKeys = map[string]func(a, b Student) bool{
+	"id":     func(a, b Student) bool { return a.ID < b.ID },
+	"name":   func(a, b Student) bool { return strings.ToLower(a.Name) < strings.ToLower(b.Name) },
+	"email":  func(a, b Student) bool { return strings.ToLower(a.Email) < strings.ToLower(b.Email) },
+	"status": func(a, b Student) bool { return a.Status < b.Status },
+}
+
+// StudentStatus state of a student session. Values unknown to this version of
+// the sdk are kept as sent by the server.
+type StudentStatus string
+
+// Documented student session states
+const (
+	StudentStatusCreated  StudentStatus = "created"
+	StudentStatusStarted  StudentStatus = "started"
+	StudentStatusFinished StudentStatus = "finished"
+	StudentStatusArchived StudentStatus = "archived"
+)
+
+// IsActive reports whether the student is taking the exam
+func (s StudentStatus) IsActive() bool {
+	return s == StudentStatusStarted
+}
+
+// IsFinished reports whether the student has handed in the exam
+func (s StudentStatus) IsFinished() bool {
+	return s == StudentStatusFinished
+}
+
+// IsArchived reports whether the session has been archived
+func (s StudentStatus) IsArchived() bool {
+	return s == StudentStatusArchived
+}
+
+// Known reports whether s is one of the documented states
+func (s StudentStatus) Known() bool {
+	switch s {
+	case StudentStatusCreated, StudentStatusStarted, StudentStatusFinished, StudentStatusArchived:
+		return true
+	}
+	return false
+}
+
+// SessionTiming scheduling and duration breakdown of a student session
+type SessionTiming struct {
+	StudentSessionID int64         `json:"student_session_id"`
+	ScheduledStart   time.Time     `json:"scheduled_start"`
+	ActualStart      time.Time     `json:"actual_start"`
+	ActualEnd        time.Time     `json:"actual_end"`
+	ActiveDuration   time.Duration `json:"-"`
+	PausedDuration   time.Duration `json:"-"`
+}
+
+// UnmarshalJSON decodes the durations, which the API reports in seconds
+func (t *SessionTiming) UnmarshalJSON(data []byte) error {
+	type alias SessionTiming
+	aux := struct {
+		*alias
+		ActiveDuration int64 `json:"active_duration"`
+		PausedDuration int64 `json:"paused_duration"`
+	}{alias: (*alias)(t)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	t.ActiveDuration = time.Duration(aux.ActiveDuration) * time.Second
+	t.PausedDuration = time.Duration(aux.PausedDuration) * time.Second
+
+	return nil
+}
+
+// IsSessionRecording GET /student_sessions/status?student_session_id=
+func (api *API) IsSessionRecording(studentSessionID int64) (bool, error) {
+	path := fmt.Sprintf("%s/student_sessions/status", apiPrefix)
+	params := getBaseParams()
+	sessionID := strconv.Itoa(int(studentSessionID))
+	params["student_session_id"] = sessionID
+	req, err := api.newGetRequest(path, params, map[string]string{"student_session_id": sessionID})
+	if err != nil {
+		return false, err
+	}
+	type statusWrapper struct {
+		Item *struct {
+			Recording bool `json:"recording"`
+		} `json:"status"`
+	}
+	var wrapper statusWrapper
+	if err = api.do(req, &wrapper); err != nil {
+		return false, sessionError(err)
+	}
+	if wrapper.Item == nil {
+		return false, ErrSessionNotFound
+	}
+
+	return wrapper.Item.Recording, nil
+}
+
+// SessionTiming GET /student_sessions/timing?student_session_id=
+func (api *API) SessionTiming(studentSessionID int64) (SessionTiming, error) {
+	path := fmt.Sprintf("%s/student_sessions/timing", apiPrefix)
+	params := getBaseParams()
+	sessionID := strconv.Itoa(int(studentSessionID))
+	params["student_session_id"] = sessionID
+	req, err := api.newGetRequest(path, params, map[string]string{"student_session_id": sessionID})
+	if err != nil {
+		return SessionTiming{}, err
+	}
+	type timingWrapper struct {
+		Item *SessionTiming `json:"timing"`
+	}
+	var wrapper timingWrapper
+	if err = api.do(req, &wrapper); err != nil {
+		return SessionTiming{}, sessionError(err)
+	}
+	if wrapper.Item == nil {
+		return SessionTiming{}, ErrSessionNotFound
+	}
+
+	return *wrapper.Item, nil
+}
+
+// ProctorActiveSessions GET /users/:id/active_sessions
+// returns the in-progress student sessions monitored by the proctor
+func (api *API) ProctorActiveSessions(userID int64) ([]Student, error) {
+	path := fmt.Sprintf("%s/users/%d/active_sessions", apiPrefix, userID)
+	params := getBaseParams()
+	params["id"] = strconv.Itoa(int(userID))
+	req, err := api.newGetRequest(path, params, nil)
+	if err != nil {
+		return nil, err
+	}
+	type studentsWrapper struct {
+		Items []Student `json:"students"`
+	}
+	var students studentsWrapper
+	err = api.do(req, &students)
+
+	return students.Items, err
+}
+
+// StudentHistory GET /institutes/:institute_id/student_sessions?email=
+// returns the sessions of a student across every exam of the institute
+func (api *API) StudentHistory(instituteID int64, email string) ([]Student, error) {
+	path := fmt.Sprintf("%s/institutes/%d/student_sessions", apiPrefix, instituteID)
+	params := getBaseParams()
+	params["institute_id"] = strconv.Itoa(int(instituteID))
+	params["email"] = email
+	req, err := api.newGetRequest(path, params, map[string]string{"email": email})
+	if err != nil {
+		return nil, err
+	}
+	type studentsWrapper struct {
+		Items []Student `json:"students"`
+	}
+	var students studentsWrapper
+	err = api.do(req, &students)
+
+	return students.Items, err
+}
+
+// StudentExamWindow GET /student_sessions/exam_window?student_session_id=
+// returns the window in which the student is scheduled to take the exam
+func (api *API) StudentExamWindow(studentSessionID int64) (start, end time.Time, err error) {
+	path := fmt.Sprintf("%s/student_sessions/exam_window", apiPrefix)
+	params := getBaseParams()
+	sessionID := strconv.Itoa(int(studentSessionID))
+	params["student_session_id"] = sessionID
+	req, err := api.newGetRequest(path, params, map[string]string{"student_session_id": sessionID})
+	if err != nil {
+		return start, end, err
+	}
+	type windowWrapper struct {
+		Item *struct {
+			StartsAt string `json:"starts_at"`
+			EndsAt   string `json:"ends_at"`
+		} `json:"window"`
+	}
+	var wrapper windowWrapper
+	if err = api.do(req, &wrapper); err != nil {
+		return start, end, sessionError(err)
+	}
+	if wrapper.Item == nil {
+		return start, end, ErrSessionNotFound
+	}
+	if start, err = time.Parse(time.RFC3339, wrapper.Item.StartsAt); err != nil {
+		return start, end, err
+	}
+	end, err = time.Parse(time.RFC3339, wrapper.Item.EndsAt)
+
+	return start, end, err
+}
+
+// StudentInput candidate enrolled in an exam
+type StudentInput struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// CreateStudent POST /exams/:id/students
+// enrolls a candidate in the exam and returns the created student session
+func (api *API) CreateStudent(examID int64, student StudentInput) (Student, error) {
+	path := fmt.Sprintf("%s/exams/%d/students", apiPrefix, examID)
+	params := getBaseParams()
+	params["id"] = strconv.Itoa(int(examID))
+	body := struct {
+		Student StudentInput `json:"student"`
+	}{student}
+	req, err := api.newPostRequest(path, body, params, nil)
+	if err != nil {
+		return Student{}, err
+	}
+	type studentWrapper struct {
+		Item Student `json:"student"`
+	}
+	var wrapper studentWrapper
+	err = api.do(req, &wrapper)
+
+	return wrapper.Item, err
+}
+
+// StartExam POST /exams/:id/start_exam?student_session_id=
+// starts the proctored session of an enrolled student
+func (api *API) StartExam(examID, studentSessionID int64) (Student, error) {
+	path := fmt.Sprintf("%s/exams/%d/start_exam", apiPrefix, examID)
+	params := getBaseParams()
+	sessionID := strconv.Itoa(int(studentSessionID))
+	params["student_session_id"] = sessionID
+	params["id"] = strconv.Itoa(int(examID))
+	req, err := api.newPostRequest(path, nil, params, map[string]string{"student_session_id": sessionID})
+	if err != nil {
+		return Student{}, err
+	}
+	type studentWrapper struct {
+		Item Student `json:"student"`
+	}
+	var wrapper studentWrapper
+	if err = api.do(req, &wrapper); err != nil {
+		return Student{}, sessionError(err)
+	}
+
+	return wrapper.Item, nil
+}
+
+// StopExam POST /exams/:id/stop_exam?student_session_id=
+// terminates the live proctored session of a student
+func (api *API) StopExam(examID, studentSessionID int64) error {
+	path := fmt.Sprintf("%s/exams/%d/stop_exam", apiPrefix, examID)
+	params := getBaseParams()
+	sessionID := strconv.Itoa(int(studentSessionID))
+	params["student_session_id"] = sessionID
+	params["id"] = strconv.Itoa(int(examID))
+	req, err := api.newPostRequest(path, nil, params, map[string]string{"student_session_id": sessionID})
+	if err != nil {
+		return err
+	}
+
+	return sessionError(api.do(req, nil))
+}
+
+// ArchiveStudent PATCH /exams/:id/archive_student?student_session_id=
+// archives the session of a student and returns it
+func (api *API) ArchiveStudent(examID, studentSessionID int64) (Student, error) {
+	path := fmt.Sprintf("%s/exams/%d/archive_student", apiPrefix, examID)
+	params := getBaseParams()
+	sessionID := strconv.Itoa(int(studentSessionID))
+	params["student_session_id"] = sessionID
+	params["id"] = strconv.Itoa(int(examID))
+	req, err := api.newPatchRequest(path, nil, params, map[string]string{"student_session_id": sessionID})
+	if err != nil {
+		return Student{}, err
+	}
+	type studentWrapper struct {
+		Item Student `json:"student"`
+	}
+	var wrapper studentWrapper
+	if err = api.do(req, &wrapper); err != nil {
+		return Student{}, sessionError(err)
+	}
+
+	return wrapper.Item, nil
+}
+
+// IndexStudentsSorted GET /exams/:id/index_students?sort=&order=
+// returns the students of the exam ordered by sortBy ("id", "name", "email"
+// or "status") in the given direction ("asc" or "desc"). The students are
+// also sorted client-side, with a stable sort, in case the server ignores the
+// ordering params.
+func (api *API) IndexStudentsSorted(examID int64, sortBy, direction string) ([]Student, error) {
+	less, ok := studentSortKeys[sortBy]
+	if !ok {
+		return nil, fmt.Errorf("proctorexam: unknown sort field %q", sortBy)
+	}
+	if direction != "asc" && direction != "desc" {
+		return nil, fmt.Errorf("proctorexam: unknown sort direction %q", direction)
+	}
+	path := fmt.Sprintf("%s/exams/%d/index_students", apiPrefix, examID)
+	params := getBaseParams()
+	params["id"] = strconv.Itoa(int(examID))
+	params["sort"] = sortBy
+	params["order"] = direction
+	req, err := api.newGetRequest(path, params, map[string]string{"sort": sortBy, "order": direction})
+	if err != nil {
+		return nil, err
+	}
+	type studentsWrapper struct {
+		Items []Student `json:"students"`
+	}
+	var students studentsWrapper
+	if err = api.do(req, &students); err != nil {
+		return students.Items, err
+	}
+	sort.SliceStable(students.Items, func(i, j int) bool {
+		if direction == "desc" {
+			return less(students.Items[j], students.Items[i])
+		}
+		return less(students.Items[i], students.Items[j])
+	})
+
+	return students.Items, nil
+}
+
+// StudentUpdate changes applied by UpdateStudentSession. Nil fields are left
+// untouched.
+type StudentUpdate struct {
+	Name  *string
+	Email *string
+	// ExtraTime added to the exam duration of the student
+	ExtraTime *time.Duration
+}
+
+// MarshalJSON encodes the extra time in seconds, like the API reports
+// durations
+func (u StudentUpdate) MarshalJSON() ([]byte, error) {
+	aux := struct {
+		Name      *string `json:"name,omitempty"`
+		Email     *string `json:"email,omitempty"`
+		ExtraTime *int64  `json:"extra_time,omitempty"`
+	}{Name: u.Name, Email: u.Email}
+	if u.ExtraTime != nil {
+		seconds := int64(*u.ExtraTime / time.Second)
+		aux.ExtraTime = &seconds
+	}
+	return json.Marshal(aux)
+}
+
+// UpdateStudentSession PATCH /exams/:id/students/:student_session_id
+func (api *API) UpdateStudentSession(examID, studentSessionID int64, changes StudentUpdate) (Student, error) {
+	path := fmt.Sprintf("%s/exams/%d/students/%d", apiPrefix, examID, studentSessionID)
+	params := getBaseParams()
+	params["id"] = strconv.Itoa(int(examID))
+	params["student_session_id"] = strconv.Itoa(int(studentSessionID))
+	body := struct {
+		Student StudentUpdate `json:"student"`
+	}{changes}
+	req, err := api.newPatchRequest(path, body, params, nil)
+	if err != nil {
+		return Student{}, err
+	}
+	type studentWrapper struct {
+		Item Student `json:"student"`
+	}
+	var wrapper studentWrapper
+	if err = api.do(req, &wrapper); err != nil {
+		return Student{}, sessionError(err)
+	}
+
+	return wrapper.Item, nil
+}
+
+// DeleteStudentSession DELETE /exams/:id/students/:student_session_id
+// unregisters a student. A session that has already started can not be
+// deleted, the server answers with 409 Conflict which is reported as
+// ErrSessionStarted.
+func (api *API) DeleteStudentSession(examID, studentSessionID int64) error {
+	path := fmt.Sprintf("%s/exams/%d/students/%d", apiPrefix, examID, studentSessionID)
+	params := getBaseParams()
+	params["id"] = strconv.Itoa(int(examID))
+	params["student_session_id"] = strconv.Itoa(int(studentSessionID))
+	req, err := api.newDeleteRequest(path, params, nil)
+	if err != nil {
+		return err
+	}
+	err = api.do(req, nil)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict {
+		return fmt.Errorf("%w: %w", ErrSessionStarted, err)
+	}
+
+	return sessionError(err)
+}
+
+// ResetStudentSession POST /exams/:id/reset_student?student_session_id=
+// resets the session of a student so the exam can be retaken, e.g. after
+// technical issues, and returns the refreshed session
+func (api *API) ResetStudentSession(examID, studentSessionID int64) (Student, error) {
+	path := fmt.Sprintf("%s/exams/%d/reset_student", apiPrefix, examID)
+	params := getBaseParams()
+	sessionID := strconv.Itoa(int(studentSessionID))
+	params["student_session_id"] = sessionID
+	params["id"] = strconv.Itoa(int(examID))
+	req, err := api.newPostRequest(path, nil, params, map[string]string{"student_session_id": sessionID})
+	if err != nil {
+		return Student{}, err
+	}
+	type studentWrapper struct {
+		Item Student `json:"student"`
+	}
+	var wrapper studentWrapper
+	if err = api.do(req, &wrapper); err != nil {
+		return Student{}, sessionError(err)
+	}
+
+	return wrapper.Item, nil
+}
+
+// ResendInvitation POST /exams/:id/resend_invitation?student_session_id=
+// sends the invitation email to the student again
+func (api *API) ResendInvitation(examID, studentSessionID int64) error {
+	path := fmt.Sprintf("%s/exams/%d/resend_invitation", apiPrefix, examID)
+	params := getBaseParams()
+	sessionID := strconv.Itoa(int(studentSessionID))
+	params["student_session_id"] = sessionID
+	params["id"] = strconv.Itoa(int(examID))
+	req, err := api.newPostRequest(path, nil, params, map[string]string{"student_session_id": sessionID})
+	if err != nil {
+		return err
+	}
+
+	return sessionError(api.do(req, nil))
+}
+
+// ExtendSessionDeadline PATCH /exams/:id/extend_deadline?student_session_id=
+// moves the date by which the student has to take the exam to deadline and
+// returns the updated session
+func (api *API) ExtendSessionDeadline(examID, studentSessionID int64, deadline time.Time) (Student, error) {
+	path := fmt.Sprintf("%s/exams/%d/extend_deadline", apiPrefix, examID)
+	params := getBaseParams()
+	sessionID := strconv.Itoa(int(studentSessionID))
+	params["student_session_id"] = sessionID
+	params["id"] = strconv.Itoa(int(examID))
+	body := struct {
+		Deadline string `json:"deadline"`
+	}{deadline.Format(time.RFC3339)}
+	req, err := api.newPatchRequest(path, body, params, map[string]string{"student_session_id": sessionID})
+	if err != nil {
+		return Student{}, err
+	}
+	type studentWrapper struct {
+		Item Student `json:"student"`
+	}
+	var wrapper studentWrapper
+	if err = api.do(req, &wrapper); err != nil {
+		return Student{}, sessionError(err)
+	}
+
+	return wrapper.Item, nil
+}
+
+// ReopenSession POST /exams/:id/reopen_student?student_session_id=
+// reopens a session that ended prematurely and returns it. The server answers
+// 409 Conflict or 422 Unprocessable Entity when the session can not be
+// reopened, reported as ErrReopenNotAllowed.
+func (api *API) ReopenSession(examID, studentSessionID int64) (Student, error) {
+	path := fmt.Sprintf("%s/exams/%d/reopen_student", apiPrefix, examID)
+	params := getBaseParams()
+	sessionID := strconv.Itoa(int(studentSessionID))
+	params["student_session_id"] = sessionID
+	params["id"] = strconv.Itoa(int(examID))
+	req, err := api.newPostRequest(path, nil, params, map[string]string{"student_session_id": sessionID})
+	if err != nil {
+		return Student{}, err
+	}
+	type studentWrapper struct {
+		Item Student `json:"student"`
+	}
+	var wrapper studentWrapper
+	if err = api.do(req, &wrapper); err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusConflict ||
+			apiErr.StatusCode == http.StatusUnprocessableEntity) {
+			return Student{}, fmt.Errorf("%w: %w", ErrReopenNotAllowed, err)
+		}
+		return Student{}, sessionError(err)
+	}
+
+	return wrapper.Item, nil
+}
+
+// StudentResult outcome of the registration of one student by
+// CreateStudents
+type StudentResult struct {
+	Input   StudentInput
+	Student Student
+	Err     error
+}
+
+// CreateStudents POST /exams/:id/students/bulk
+// enrolls several candidates in the exam. When the bulk endpoint is not
+// available (404 or 405), the students are created one by one with
+// CreateStudent, concurrently. The results are in the order of students; the
+// returned error is only set when no student could be processed at all.
+func (api *API) CreateStudents(examID int64, students []StudentInput) ([]StudentResult, error) {
+	results, err := api.createStudentsBulk(examID, students)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusNotFound ||
+		apiErr.StatusCode == http.StatusMethodNotAllowed) {
+		return api.createStudentsOneByOne(examID, students), nil
+	}
+
+	return results, err
+}
+
+func (api *API) createStudentsBulk(examID int64, students []StudentInput) ([]StudentResult, error) {
+	path := fmt.Sprintf("%s/exams/%d/students/bulk", apiPrefix, examID)
+	params := getBaseParams()
+	params["id"] = strconv.Itoa(int(examID))
+	body := struct {
+		Students []StudentInput `json:"students"`
+	}{students}
+	req, err := api.newPostRequest(path, body, params, nil)
+	if err != nil {
+		return nil, err
+	}
+	type resultsWrapper struct {
+		Items []struct {
+			Student Student `json:"student"`
+			Error   string  `json:"error"`
+		} `json:"results"`
+	}
+	var wrapper resultsWrapper
+	if err = api.do(req, &wrapper); err != nil {
+		return nil, err
+	}
+	if len(wrapper.Items) != len(students) {
+		return nil, fmt.Errorf("proctorexam: bulk registration returned %d results for %d students",
+			len(wrapper.Items), len(students))
+	}
+
+	results := make([]StudentResult, len(students))
+	for i, item := range wrapper.Items {
+		results[i] = StudentResult{Input: students[i], Student: item.Student}
+		if item.Error != "" {
+			results[i].Err = errors.New("proctorexam: " + item.Error)
+		}
+	}
+
+	return results, nil
+}
+
+func (api *API) createStudentsOneByOne(examID int64, students []StudentInput) []StudentResult {
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, maxParallelRequests)
+	)
+	results := make([]StudentResult, len(students))
+	for i, student := range students {
+		wg.Add(1)
+		go func(i int, student StudentInput) {
+			defer wg.Done()
+			results[i].Input = student
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-api.context().Done():
+				results[i].Err = api.context().Err()
+				return
+			}
+			results[i].Student, results[i].Err = api.CreateStudent(examID, student)
+		}(i, student)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// StudentLaunchURL returns the link the candidate opens to take the exam, as
+// issued by the server in show_student: the SSO link, or the secure browser
+// url for exams run in a secure browser. The links are signed server-side, so
+// they can be embedded in a portal as is. The system check link is returned by
+// CreateStudent as Student.CheckURL.
+func (api *API) StudentLaunchURL(examID, studentSessionID int64) (string, error) {
+	student, err := api.ShowStudent(examID, studentSessionID)
+	if err != nil {
+		return "", sessionError(err)
+	}
+	switch {
+	case student.SSOLink != "":
+		return student.SSOLink, nil
+	case student.SecureBrowserURL != "":
+		return student.SecureBrowserURL, nil
+	}
+
+	return "", ErrNoLaunchURL
+}
@@ -0,0 +1,586 @@
+package proctorexam
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSessionRecording(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	fixtures := map[string]string{
+		"4": "session_status_recording.json",
+		"5": "session_status_idle.json",
+	}
+	mux.HandleFunc("/api/v3/student_sessions/status", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		name, ok := fixtures[r.URL.Query().Get("student_session_id")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, fixture("session_not_found.json"))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, fixture(name))
+	})
+
+	recording, err := api.IsSessionRecording(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, recording)
+
+	recording, err = api.IsSessionRecording(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, recording)
+
+	_, err = api.IsSessionRecording(6)
+	assert.ErrorIs(t, err, ErrSessionNotFound)
+	var apiErr *APIError
+	assert.ErrorAs(t, err, &apiErr)
+}
+
+func TestSessionTiming(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v3/student_sessions/timing", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "4", r.URL.Query().Get("student_session_id"))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, fixture("session_timing.json"))
+	})
+
+	timing, err := api.SessionTiming(idStudSession)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, int64(idStudSession), timing.StudentSessionID)
+	assert.Equal(t, time.Date(2020, 3, 2, 9, 0, 0, 0, time.UTC), timing.ScheduledStart)
+	assert.Equal(t, time.Date(2020, 3, 2, 9, 4, 12, 0, time.UTC), timing.ActualStart)
+	assert.Equal(t, time.Date(2020, 3, 2, 10, 41, 57, 0, time.UTC), timing.ActualEnd)
+	assert.Equal(t, 91*time.Minute+45*time.Second, timing.ActiveDuration)
+	assert.Equal(t, 6*time.Minute, timing.PausedDuration)
+}
+
+func TestProctorActiveSessions(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	path := fmt.Sprintf("/api/v3/users/%d/active_sessions", idUser)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, fixture("proctor_active_sessions.json"))
+	})
+
+	students, err := api.ProctorActiveSessions(idUser)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(students), 2)
+	for _, student := range students {
+		assert.Equal(t, StudentStatusStarted, student.Status)
+	}
+	assert.Equal(t, int(students[0].ID), idStudent)
+}
+
+func TestStudentHistory(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	email := "jane+retake@example.com"
+	path := fmt.Sprintf("/api/v3/institutes/%d/student_sessions", idInst)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		assert.Equal(t, email, query.Get("email"))
+		params := map[string]string{
+			"nonce":        query.Get("nonce"),
+			"timestamp":    query.Get("timestamp"),
+			"institute_id": fmt.Sprint(idInst),
+			"email":        email,
+		}
+		assert.Equal(t, api.signParams(params), query.Get("signature"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, fixture("student_history.json"))
+	})
+
+	students, err := api.StudentHistory(idInst, email)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(students), 2)
+	for _, student := range students {
+		assert.Equal(t, email, student.Email)
+	}
+}
+
+func TestStudentExamWindow(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v3/student_sessions/exam_window", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "4", r.URL.Query().Get("student_session_id"))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, fixture("student_exam_window.json"))
+	})
+
+	start, end, err := api.StudentExamWindow(idStudSession)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, start.Equal(time.Date(2020, 3, 2, 8, 0, 0, 0, time.UTC)), "start: %v", start)
+	assert.True(t, end.Equal(time.Date(2020, 3, 4, 17, 0, 0, 0, time.UTC)), "end: %v", end)
+}
+
+func TestCreateStudent(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	path := fmt.Sprintf("/api/v3/exams/%d/students", idExam)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var body struct {
+			Student StudentInput `json:"student"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, StudentInput{Name: "Jane Doe", Email: "jane.doe@example.com"}, body.Student)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, fixture("create_student.json"))
+	})
+
+	student, err := api.CreateStudent(idExam, StudentInput{Name: "Jane Doe", Email: "jane.doe@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int(student.ID), idStudent)
+	assert.Equal(t, StudentStatusCreated, student.Status)
+	assert.Equal(t, "https://protos.proctorexam.com/system_check/7f3a9c", student.CheckURL)
+}
+
+func TestStartExam(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	path := fmt.Sprintf("/api/v3/exams/%d/start_exam", idExam)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "4", r.URL.Query().Get("student_session_id"))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, fixture("start_exam.json"))
+	})
+
+	student, err := api.StartExam(idExam, idStudSession)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, StudentStatusStarted, student.Status)
+}
+
+func TestShowStudentLinks(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/show_student", idExam), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, fixture("student_links.json"))
+	})
+
+	student, err := api.ShowStudent(idExam, idStudSession)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "https://protos.proctorexam.com/sso/7f3a9c?token=abc123", student.SSOLink)
+	assert.Equal(t, "seb://protos.proctorexam.com/exams/17/student_sessions/4.seb", student.SecureBrowserURL)
+	assert.Equal(t, "https://protos.proctorexam.com/uploads/7f3a9c", student.UploadLink)
+}
+
+func TestStudentStatus(t *testing.T) {
+	cases := []struct {
+		status   StudentStatus
+		active   bool
+		finished bool
+		archived bool
+		known    bool
+	}{
+		{StudentStatusCreated, false, false, false, true},
+		{StudentStatusStarted, true, false, false, true},
+		{StudentStatusFinished, false, true, false, true},
+		{StudentStatusArchived, false, false, true, true},
+		{"paused", false, false, false, false},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.active, c.status.IsActive(), string(c.status))
+		assert.Equal(t, c.finished, c.status.IsFinished(), string(c.status))
+		assert.Equal(t, c.archived, c.status.IsArchived(), string(c.status))
+		assert.Equal(t, c.known, c.status.Known(), string(c.status))
+	}
+
+	var student Student
+	if err := json.Unmarshal([]byte(`{"id": 804, "status": "paused"}`), &student); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, StudentStatus("paused"), student.Status)
+}
+
+func TestStopExam(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	path := fmt.Sprintf("/api/v3/exams/%d/stop_exam", idExam)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assertSigned(t, r, map[string]string{"id": fmt.Sprint(idExam)})
+		if r.URL.Query().Get("student_session_id") != "4" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	assert.NoError(t, api.StopExam(idExam, idStudSession))
+
+	err := api.StopExam(idExam, 99)
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.True(t, errors.Is(err, ErrSessionNotFound))
+}
+
+func TestArchiveStudent(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	path := fmt.Sprintf("/api/v3/exams/%d/archive_student", idExam)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PATCH", r.Method)
+		assert.Equal(t, "4", r.URL.Query().Get("student_session_id"))
+		assertSigned(t, r, map[string]string{"id": fmt.Sprint(idExam)})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"student": {"id": %d, "status": "archived"}}`, idStudent)
+	})
+
+	student, err := api.ArchiveStudent(idExam, idStudSession)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, student.Status.IsArchived())
+}
+
+func TestIndexStudentsSorted(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	path := fmt.Sprintf("/api/v3/exams/%d/index_students", idExam)
+
+	var orders []string
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "name", r.URL.Query().Get("sort"))
+		assertSigned(t, r, map[string]string{"id": fmt.Sprint(idExam)})
+		orders = append(orders, r.URL.Query().Get("order"))
+		// the server ignores the ordering
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"students": [{"id": 1, "name": "bob"}, {"id": 2, "name": "Alice"}, {"id": 3, "name": "carol"}]}`)
+	})
+
+	names := func(students []Student) []string {
+		var names []string
+		for _, student := range students {
+			names = append(names, student.Name)
+		}
+		return names
+	}
+
+	students, err := api.IndexStudentsSorted(idExam, "name", "asc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"Alice", "bob", "carol"}, names(students))
+
+	students, err = api.IndexStudentsSorted(idExam, "name", "desc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"carol", "bob", "Alice"}, names(students))
+	assert.Equal(t, []string{"asc", "desc"}, orders)
+
+	_, err = api.IndexStudentsSorted(idExam, "password", "asc")
+	assert.Error(t, err)
+	_, err = api.IndexStudentsSorted(idExam, "name", "up")
+	assert.Error(t, err)
+}
+
+func TestUpdateStudentSession(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	path := fmt.Sprintf("/api/v3/exams/%d/students/%d", idExam, idStudSession)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PATCH", r.Method)
+		assertSigned(t, r, map[string]string{"id": fmt.Sprint(idExam), "student_session_id": fmt.Sprint(idStudSession)})
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"student": {"email": "jane@example.org", "extra_time": 900}}`, string(body))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"student": {"id": %d, "email": "jane@example.org"}}`, idStudent)
+	})
+
+	email := "jane@example.org"
+	extra := 15 * time.Minute
+	student, err := api.UpdateStudentSession(idExam, idStudSession, StudentUpdate{Email: &email, ExtraTime: &extra})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "jane@example.org", student.Email)
+
+	_, err = api.UpdateStudentSession(idExam, 99, StudentUpdate{Email: &email})
+	assert.True(t, errors.Is(err, ErrSessionNotFound))
+}
+
+func TestDeleteStudentSession(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/students/%d", idExam, idStudSession), func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		assertSigned(t, r, map[string]string{"id": fmt.Sprint(idExam), "student_session_id": fmt.Sprint(idStudSession)})
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/students/5", idExam), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprint(w, `{"error": "session already started"}`)
+	})
+
+	assert.NoError(t, api.DeleteStudentSession(idExam, idStudSession))
+
+	err := api.DeleteStudentSession(idExam, 5)
+	assert.True(t, errors.Is(err, ErrSessionStarted))
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+
+	err = api.DeleteStudentSession(idExam, 99)
+	assert.True(t, errors.Is(err, ErrSessionNotFound))
+}
+
+func TestResetStudentSession(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/reset_student", idExam), func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "4", r.URL.Query().Get("student_session_id"))
+		assertSigned(t, r, map[string]string{"id": fmt.Sprint(idExam)})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"student": {"id": %d, "status": "created"}}`, idStudent)
+	})
+
+	student, err := api.ResetStudentSession(idExam, idStudSession)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, StudentStatusCreated, student.Status)
+}
+
+func TestResendInvitation(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	sent := 0
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/resend_invitation", idExam), func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assertSigned(t, r, map[string]string{"id": fmt.Sprint(idExam)})
+		if r.URL.Query().Get("student_session_id") != "4" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		sent++
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	assert.NoError(t, api.ResendInvitation(idExam, idStudSession))
+	assert.Equal(t, 1, sent)
+
+	err := api.ResendInvitation(idExam, 99)
+	assert.True(t, errors.Is(err, ErrSessionNotFound))
+}
+
+func TestExtendSessionDeadline(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/extend_deadline", idExam), func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PATCH", r.Method)
+		assert.Equal(t, "4", r.URL.Query().Get("student_session_id"))
+		assertSigned(t, r, map[string]string{"id": fmt.Sprint(idExam)})
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"deadline": "2026-06-19T23:59:00+02:00"}`, string(body))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"student": {"id": %d}}`, idStudent)
+	})
+
+	deadline := time.Date(2026, 6, 19, 23, 59, 0, 0, time.FixedZone("CEST", 2*60*60))
+	student, err := api.ExtendSessionDeadline(idExam, idStudSession, deadline)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(idStudent), student.ID)
+}
+
+func TestReopenSession(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/reopen_student", idExam), func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assertSigned(t, r, map[string]string{"id": fmt.Sprint(idExam)})
+		switch r.URL.Query().Get("student_session_id") {
+		case "4":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{"student": {"id": %d, "status": "started"}}`, idStudent)
+		case "5":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprint(w, `{"error": "session already reviewed"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	student, err := api.ReopenSession(idExam, idStudSession)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, student.Status.IsActive())
+
+	_, err = api.ReopenSession(idExam, 5)
+	assert.True(t, errors.Is(err, ErrReopenNotAllowed))
+
+	_, err = api.ReopenSession(idExam, 99)
+	assert.True(t, errors.Is(err, ErrSessionNotFound))
+}
+
+func TestCreateStudentsBulk(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/students/bulk", idExam), func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assertSigned(t, r, map[string]string{"id": fmt.Sprint(idExam)})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"results": [
+			{"student": {"id": 900, "email": "a@example.com"}},
+			{"error": "email is invalid"}
+		]}`)
+	})
+
+	results, err := api.CreateStudents(idExam, []StudentInput{
+		{Name: "A", Email: "a@example.com"},
+		{Name: "B", Email: "b@"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, len(results))
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, int64(900), results[0].Student.ID)
+	assert.Error(t, results[1].Err)
+	assert.Equal(t, "b@", results[1].Input.Email)
+}
+
+func TestCreateStudentsFallback(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	created := 0
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/students", idExam), func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Student StudentInput `json:"student"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		if body.Student.Email == "b@" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+		mu.Lock()
+		created++
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"student": {"email": %q}}`, body.Student.Email)
+	})
+
+	students := []StudentInput{{Email: "a@example.com"}, {Email: "b@"}, {Email: "c@example.com"}}
+	results, err := api.CreateStudents(idExam, students)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, created)
+	for i, result := range results {
+		assert.Equal(t, students[i], result.Input)
+	}
+	assert.Equal(t, "a@example.com", results[0].Student.Email)
+	var apiErr *APIError
+	assert.ErrorAs(t, results[1].Err, &apiErr)
+	assert.NoError(t, results[2].Err)
+}
+
+func TestStudentLaunchURL(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/show_student", idExam), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("student_session_id") != fmt.Sprint(idStudSession) {
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `{"student": {"id": 99, "status": "created"}}`)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, fixture("student_links.json"))
+	})
+
+	link, err := api.StudentLaunchURL(idExam, idStudSession)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "https://protos.proctorexam.com/sso/7f3a9c?token=abc123", link)
+
+	_, err = api.StudentLaunchURL(idExam, 99)
+	assert.ErrorIs(t, err, ErrNoLaunchURL)
+}
@@ -0,0 +1,52 @@
+package proctorexam
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrNoRequestStats is returned by LastRequestStats before any request has
+// completed
+var ErrNoRequestStats = errors.New("proctorexam: no request completed yet")
+
+// RequestStats metrics of the last completed request
+type RequestStats struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Duration   time.Duration
+	Retries    int
+	BytesRead  int64
+}
+
+// statsRecorder keeps the stats of the last request. It is shared by the
+// copies of a client returned by WithContext.
+type statsRecorder struct {
+	mu   sync.Mutex
+	last *RequestStats
+}
+
+func (r *statsRecorder) record(stats RequestStats) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.last = &stats
+}
+
+// LastRequestStats returns the metrics of the most recent request that got a
+// response. It is safe to call concurrently with other calls.
+func (api *API) LastRequestStats() (RequestStats, error) {
+	if api.stats == nil {
+		return RequestStats{}, ErrNoRequestStats
+	}
+	api.stats.mu.Lock()
+	defer api.stats.mu.Unlock()
+	if api.stats.last == nil {
+		return RequestStats{}, ErrNoRequestStats
+	}
+
+	return *api.stats.last, nil
+}
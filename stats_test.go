@@ -0,0 +1,40 @@
+package proctorexam
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLastRequestStats(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	body := `{"exams": [{"id": 17, "institute_id": 17, "name": "Physics"}]}`
+	mux.HandleFunc("/api/v3/exams", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, body)
+	})
+
+	_, err := api.LastRequestStats()
+	assert.Equal(t, ErrNoRequestStats, err)
+
+	_, err = api.Exams()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := api.LastRequestStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "GET", stats.Method)
+	assert.Equal(t, "/api/v3/exams", stats.Path)
+	assert.Equal(t, http.StatusOK, stats.StatusCode)
+	assert.Equal(t, int64(len(body)), stats.BytesRead)
+	assert.Equal(t, 0, stats.Retries)
+	assert.True(t, stats.Duration > 0)
+}
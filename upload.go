@@ -0,0 +1,74 @@
+package proctorexam
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"strings"
+)
+
+// UploadFile POST /exams/:id/upload_file
+// uploads the content of r as a multipart/form-data part named field, e.g. an
+// instruction PDF or a candidate ID photo. The content is streamed, not
+// loaded in memory.
+func (api *API) UploadFile(examID int64, field string, filename string, r io.Reader) error {
+	path := fmt.Sprintf("%s/exams/%d/upload_file", apiPrefix, examID)
+	params := getBaseParams()
+	params["id"] = strconv.Itoa(int(examID))
+	req, err := api.newMultipartRequest(path, params, field, filename, "", r)
+	if err != nil {
+		return err
+	}
+	defer req.Body.Close()
+
+	return api.do(req, nil)
+}
+
+// UploadInstructions uploads the instructions document (e.g. a PDF) shown to
+// the students of the exam
+func (api *API) UploadInstructions(examID int64, filename string, r io.Reader) error {
+	return api.UploadFile(examID, "instructions", filename, r)
+}
+
+// newMultipartRequest builds a signed POST request streaming r as the
+// multipart/form-data part named field. The caller must close the request
+// body if the request is not sent, to release the encoding goroutine.
+func (api *API) newMultipartRequest(path string, params map[string]string, field, filename, contentType string, r io.Reader) (*http.Request, error) {
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	body, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+	req, err := api.newSignedRequest("POST", path, body, writer.FormDataContentType(), params, nil)
+	if err != nil {
+		body.Close()
+		return nil, err
+	}
+
+	go func() {
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+			escapeQuotes(field), escapeQuotes(filename)))
+		header.Set("Content-Type", contentType)
+		part, err := writer.CreatePart(header)
+		if err == nil {
+			_, err = io.Copy(part, r)
+		}
+		if err == nil {
+			err = writer.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	return req, nil
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// escapeQuotes escapes a Content-Disposition param like mime/multipart does
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
@@ -0,0 +1,90 @@
+package proctorexam
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUploadFile(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/upload_file", idExam), func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assertSigned(t, r, map[string]string{"id": fmt.Sprint(idExam)})
+		assert.True(t, strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data; boundary="))
+
+		file, header, err := r.FormFile("instructions")
+		if assert.NoError(t, err) {
+			defer file.Close()
+			content, _ := io.ReadAll(file)
+			assert.Equal(t, "instructions.pdf", header.Filename)
+			assert.Equal(t, "%PDF-1.4 rules", string(content))
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	err := api.UploadFile(idExam, "instructions", "instructions.pdf", strings.NewReader("%PDF-1.4 rules"))
+	assert.NoError(t, err)
+}
+
+// gatedReader returns head, then waits for open before returning tail
+type gatedReader struct {
+	head, tail *strings.Reader
+	open       chan struct{}
+}
+
+func (g *gatedReader) Read(p []byte) (int, error) {
+	if g.head.Len() > 0 {
+		return g.head.Read(p)
+	}
+	select {
+	case <-g.open:
+	case <-time.After(5 * time.Second):
+		return 0, errors.New("upload buffered before sending")
+	}
+	return g.tail.Read(p)
+}
+
+func TestUploadInstructionsStreams(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	reader := &gatedReader{
+		head: strings.NewReader("%PDF-1.4 "),
+		tail: strings.NewReader("rules"),
+		open: make(chan struct{}),
+	}
+	mux.HandleFunc(fmt.Sprintf("/api/v3/exams/%d/upload_file", idExam), func(w http.ResponseWriter, r *http.Request) {
+		// the request reaches the server before the file has been read
+		close(reader.open)
+
+		file, header, err := r.FormFile("instructions")
+		if assert.NoError(t, err) {
+			defer file.Close()
+			content, _ := io.ReadAll(file)
+			assert.Equal(t, "rules.pdf", header.Filename)
+			assert.Equal(t, "application/octet-stream", header.Header.Get("Content-Type"))
+			assert.Equal(t, "%PDF-1.4 rules", string(content))
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	assert.NoError(t, api.UploadInstructions(idExam, "rules.pdf", reader))
+}
+
+func TestUploadFileClosedClient(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	api.Close()
+	err := api.UploadFile(idExam, "instructions", "rules.pdf", strings.NewReader("%PDF-1.4 rules"))
+	assert.Equal(t, ErrClientClosed, err)
+}
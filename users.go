@@ -0,0 +1,210 @@
+package proctorexam
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrUserNotFound is returned when the user to change does not exist, e.g.
+// because it was already deleted
+var ErrUserNotFound = errors.New("proctorexam: user not found")
+
+// defaultProctorCacheTTL how long InstituteProctors keeps a proctor list
+const defaultProctorCacheTTL = 5 * time.Minute
+
+// Roles of the institute users
+const (
+	RoleAdmin    = "admin"
+	RoleProctor  = "proctor"
+	RoleReviewer = "reviewer"
+	RoleStudent  = "student"
+)
+
+// proctorRoles roles of the users who can be assigned to monitor or review
+// sessions
+var proctorRoles = map[string]bool{
+	RoleProctor:  true,
+	RoleReviewer: true,
+}
+
+// proctorCache proctor lists of InstituteProctors by institute. It is shared
+// by the copies of a client returned by WithContext.
+type proctorCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[int64]proctorEntry
+}
+
+type proctorEntry struct {
+	users   []User
+	expires time.Time
+}
+
+func (c *proctorCache) get(instituteID int64) ([]User, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[instituteID]
+	if !ok || time.Now().After(entry.expires) {
+		return nil, false
+	}
+	return append([]User(nil), entry.users...), true
+}
+
+func (c *proctorCache) put(instituteID int64, users []User) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ttl == 0 {
+		return
+	}
+	if c.entries == nil {
+		c.entries = map[int64]proctorEntry{}
+	}
+	c.entries[instituteID] = proctorEntry{
+		users:   append([]User(nil), users...),
+		expires: time.Now().Add(c.ttl),
+	}
+}
+
+func (c *proctorCache) invalidate(instituteID int64) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, instituteID)
+}
+
+// InstituteProctors returns the users of the institute with a proctor or
+// reviewer role. The v3 users endpoint has no role filter, so every page of
+// users is fetched and filtered client-side. The list is cached for the
+// ProctorCacheTTL (5 minutes by default); CreateUser, UpdateUser and
+// DeleteUser drop the cached list of their institute, and InvalidateProctors
+// drops it after changes made outside the client.
+func (api *API) InstituteProctors(instituteID int64) ([]User, error) {
+	if proctors, ok := api.proctors.get(instituteID); ok {
+		return proctors, nil
+	}
+	users, err := api.UsersAll(instituteID)
+	if err != nil {
+		return nil, err
+	}
+	var proctors []User
+	for _, user := range users {
+		if proctorRoles[user.Role] {
+			proctors = append(proctors, user)
+		}
+	}
+	api.proctors.put(instituteID, proctors)
+
+	return proctors, nil
+}
+
+// InvalidateProctors drops the cached proctor list of the institute, so the
+// next InstituteProctors call fetches it again
+func (api *API) InvalidateProctors(instituteID int64) {
+	api.proctors.invalidate(instituteID)
+}
+
+// UsersByRole returns the users of the institute with the given role, e.g.
+// RoleStudent. Like InstituteProctors, it filters every page of users
+// client-side as the v3 users endpoint has no role filter.
+func (api *API) UsersByRole(instituteID int64, role string) ([]User, error) {
+	users, err := api.UsersAll(instituteID)
+	if err != nil {
+		return nil, err
+	}
+	var matching []User
+	for _, user := range users {
+		if user.Role == role {
+			matching = append(matching, user)
+		}
+	}
+
+	return matching, nil
+}
+
+// UserInput institute user created by CreateUser
+type UserInput struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	// Role e.g. RoleProctor or RoleAdmin
+	Role string `json:"role"`
+}
+
+// CreateUser POST /institutes/:institute_id/users
+func (api *API) CreateUser(instituteID int64, user UserInput) (User, error) {
+	path := fmt.Sprintf("%s/institutes/%d/users", apiPrefix, instituteID)
+	params := getBaseParams()
+	params["institute_id"] = strconv.Itoa(int(instituteID))
+	body := struct {
+		User UserInput `json:"user"`
+	}{user}
+	req, err := api.newPostRequest(path, body, params, nil)
+	if err != nil {
+		return User{}, err
+	}
+	type userWrapper struct {
+		Item User `json:"user"`
+	}
+	var wrapper userWrapper
+	err = api.do(req, &wrapper)
+	api.proctors.invalidate(instituteID)
+
+	return wrapper.Item, err
+}
+
+// UserUpdate changes applied by UpdateUser. Nil fields are left untouched.
+type UserUpdate struct {
+	Name  *string `json:"name,omitempty"`
+	Email *string `json:"email,omitempty"`
+	Role  *string `json:"role,omitempty"`
+}
+
+// UpdateUser PATCH /institutes/:institute_id/users/:id
+func (api *API) UpdateUser(instituteID, userID int64, changes UserUpdate) (User, error) {
+	path := fmt.Sprintf("%s/institutes/%d/users/%d", apiPrefix, instituteID, userID)
+	params := getBaseParams()
+	params["id"] = strconv.Itoa(int(userID))
+	params["institute_id"] = strconv.Itoa(int(instituteID))
+	body := struct {
+		User UserUpdate `json:"user"`
+	}{changes}
+	req, err := api.newPatchRequest(path, body, params, nil)
+	if err != nil {
+		return User{}, err
+	}
+	type userWrapper struct {
+		Item User `json:"user"`
+	}
+	var wrapper userWrapper
+	err = api.do(req, &wrapper)
+	api.proctors.invalidate(instituteID)
+
+	return wrapper.Item, err
+}
+
+// DeleteUser DELETE /institutes/:institute_id/users/:id
+// returns an error wrapping ErrUserNotFound when the user does not exist
+func (api *API) DeleteUser(instituteID, userID int64) error {
+	path := fmt.Sprintf("%s/institutes/%d/users/%d", apiPrefix, instituteID, userID)
+	params := getBaseParams()
+	params["id"] = strconv.Itoa(int(userID))
+	params["institute_id"] = strconv.Itoa(int(instituteID))
+	req, err := api.newDeleteRequest(path, params, nil)
+	if err != nil {
+		return err
+	}
+	err = api.do(req, nil)
+	api.proctors.invalidate(instituteID)
+
+	return userError(err)
+}
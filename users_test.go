@@ -0,0 +1,155 @@
+package proctorexam
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstituteProctors(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	path := fmt.Sprintf("/api/v3/institutes/%d/users", idInst)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, fixture("users_mixed_roles.json"))
+	})
+
+	proctors, err := api.InstituteProctors(idInst)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(proctors), 2)
+	for _, user := range proctors {
+		assert.Contains(t, []string{"proctor", "reviewer"}, user.Role)
+	}
+}
+
+func TestInstituteProctorsCache(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc(fmt.Sprintf("/api/v3/institutes/%d/users", idInst), func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, fixture("users_mixed_roles.json"))
+	})
+
+	for i := 0; i < 3; i++ {
+		proctors, err := api.InstituteProctors(idInst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 2, len(proctors))
+	}
+	assert.Equal(t, 1, calls)
+
+	api.InvalidateProctors(idInst)
+	_, err := api.InstituteProctors(idInst)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	uncached, _ := New(BaseURL(api.baseURL), ProctorCacheTTL(0))
+	for i := 0; i < 2; i++ {
+		_, err := uncached.InstituteProctors(idInst)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 4, calls)
+}
+
+func TestUsersByRole(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	path := fmt.Sprintf("/api/v3/institutes/%d/users", idInst)
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, fixture("users_mixed_roles.json"))
+	})
+
+	students, err := api.UsersByRole(idInst, RoleStudent)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 1, len(students))
+	assert.Equal(t, int64(14), students[0].ID)
+}
+
+func TestCreateUser(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/institutes/%d/users", idInst), func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assertSigned(t, r, map[string]string{"institute_id": fmt.Sprint(idInst)})
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"user": {"name": "Paul Proctor", "email": "paul@example.com", "role": "proctor"}}`, string(body))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"user": {"id": 12, "name": "Paul Proctor", "email": "paul@example.com", "role": "proctor"}}`)
+	})
+
+	user, err := api.CreateUser(idInst, UserInput{Name: "Paul Proctor", Email: "paul@example.com", Role: RoleProctor})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(12), user.ID)
+	assert.Equal(t, RoleProctor, user.Role)
+}
+
+func TestUpdateUser(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/api/v3/institutes/%d/users/%d", idInst, idUser), func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PATCH", r.Method)
+		assertSigned(t, r, map[string]string{"institute_id": fmt.Sprint(idInst), "id": fmt.Sprint(idUser)})
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"user": {"role": "reviewer"}}`, string(body))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"user": {"id": %d, "role": "reviewer"}}`, idUser)
+	})
+
+	role := RoleReviewer
+	user, err := api.UpdateUser(idInst, idUser, UserUpdate{Role: &role})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, RoleReviewer, user.Role)
+}
+
+func TestDeleteUser(t *testing.T) {
+	teardown := setup()
+	defer teardown()
+
+	deleted := false
+	mux.HandleFunc(fmt.Sprintf("/api/v3/institutes/%d/users/%d", idInst, idUser), func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		assertSigned(t, r, map[string]string{"institute_id": fmt.Sprint(idInst), "id": fmt.Sprint(idUser)})
+		deleted = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	assert.NoError(t, api.DeleteUser(idInst, idUser))
+	assert.True(t, deleted)
+
+	err := api.DeleteUser(idInst, 99)
+	assert.ErrorIs(t, err, ErrUserNotFound)
+	var apiErr *APIError
+	assert.ErrorAs(t, err, &apiErr)
+}
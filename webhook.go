@@ -0,0 +1,39 @@
+package proctorexam
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+)
+
+// WebhookSignatureHeader header carrying the hex encoded HMAC-SHA256 of the
+// body of a webhook call, keyed with the institute secret key
+const WebhookSignatureHeader = "X-ProctorExam-Signature"
+
+// ErrMissingWebhookSignature is returned by VerifyWebhook when the signature
+// header is absent
+var ErrMissingWebhookSignature = errors.New("proctorexam: missing webhook signature")
+
+// ErrInvalidWebhookSignature is returned by VerifyWebhook when the signature
+// does not match the body
+var ErrInvalidWebhookSignature = errors.New("proctorexam: invalid webhook signature")
+
+// VerifyWebhook checks that body was sent by ProctorExam by recomputing its
+// signature with secret and comparing it, in constant time, to the one in the
+// WebhookSignatureHeader header. The body must be the raw bytes received,
+// before any decoding.
+func VerifyWebhook(secret string, header http.Header, body []byte) error {
+	received := header.Get(WebhookSignatureHeader)
+	if received == "" {
+		return ErrMissingWebhookSignature
+	}
+	hash := hmac.New(sha256.New, []byte(secret))
+	hash.Write(body)
+	expected := hex.EncodeToString(hash.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(received)) {
+		return ErrInvalidWebhookSignature
+	}
+	return nil
+}
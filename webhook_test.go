@@ -0,0 +1,29 @@
+package proctorexam
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyWebhook(t *testing.T) {
+	secret := "secret"
+	body := []byte(`{"event": "session.reviewed", "student_session_id": 4}`)
+	hash := hmac.New(sha256.New, []byte(secret))
+	hash.Write(body)
+	header := http.Header{}
+	header.Set(WebhookSignatureHeader, hex.EncodeToString(hash.Sum(nil)))
+
+	assert.NoError(t, VerifyWebhook(secret, header, body))
+
+	tampered := []byte(`{"event": "session.reviewed", "student_session_id": 5}`)
+	assert.Equal(t, ErrInvalidWebhookSignature, VerifyWebhook(secret, header, tampered))
+
+	assert.Equal(t, ErrInvalidWebhookSignature, VerifyWebhook("other", header, body))
+
+	assert.Equal(t, ErrMissingWebhookSignature, VerifyWebhook(secret, http.Header{}, body))
+}